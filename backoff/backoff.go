@@ -1,6 +1,8 @@
 package backoff
 
 import (
+	"context"
+	"errors"
 	"math/rand"
 	"time"
 )
@@ -13,19 +15,38 @@ type Config struct {
 	Max time.Duration
 	// Jitter is the Jitter used to randomize the next duration.
 	Jitter Jitter
+	// Strategy creates the Strategy used for calculating the next duration.
+	Strategy StrategyFactory
+	// MaxAttempts is the maximum number of durations the backoff returns before returning Stop.
+	// Zero means no limit.
+	MaxAttempts int
+	// MaxElapsedTime is the maximum time since the backoff creation (or last Reset) after which
+	// the backoff returns Stop. Zero means no limit.
+	MaxElapsedTime time.Duration
+	// Clock is used for measuring the elapsed time and waiting. Defaults to SystemClock.
+	Clock Clock
+	// Observer is notified for every duration returned by Next. It is optional.
+	Observer Observer
 }
 
+// Stop is returned by Backoff.Next when no more retries should be made.
+const Stop time.Duration = -1
+
+// ErrStopped is returned by Backoff.Wait when no more retries should be made.
+var ErrStopped = errors.New("backoff stopped")
+
 var DefaultConfig = &Config{
-	Base:   time.Second,
-	Max:    time.Second * 30, //nolint:mnd
-	Jitter: FullJitter,
+	Base:     time.Second,
+	Max:      time.Second * 30, //nolint:mnd
+	Jitter:   FullJitter,
+	Strategy: ExponentialStrategy,
 }
 
-// Backoff is used to calculate the next backoff duration using a Jitter.
+// Backoff is used to calculate the next backoff duration using a Strategy and a Jitter.
 //
 // Check here why to use Jitter - https://aws.amazon.com/blogs/architecture/exponential-backoff-and-jitter/
 //
-// The backoff is calculated by min(Max, rand(0, Base*(2^retries))).
+// With the default config the backoff is calculated by min(Max, rand(0, Base*(2^retries))).
 //
 // Example:
 //
@@ -38,21 +59,24 @@ var DefaultConfig = &Config{
 //				return nil
 //			}
 //
-//			// get the next sleep duration
-//			sleep := b.Next()
+//			// sleep for the next duration
+//			waitErr := b.Wait(ctx)
+//			if waitErr == backoff.ErrStopped {
+//				return errors.Wrap(err, "retry attempts exceeded")
+//			}
 //
-//			select {
-//			case <-time.After(sleep):
-//				continue
-//			case <-ctx.Done():
+//			if waitErr != nil {
 //				return errors.Wrap(err, "retry canceled")
 //			}
 //		}
 type Backoff struct {
 	config *Config
 
-	randomGen  RandomGenerator
-	retryCount uint
+	randomGen RandomGenerator
+	clock     Clock
+	strategy  Strategy
+	attempt   int
+	startTime time.Time
 }
 
 // NewBackoff uses the golang rand generator from the standard library.
@@ -81,6 +105,11 @@ func NewBackoff(config *Config) *Backoff {
 //			backoff.DefaultConfig
 //		)
 func NewBackoffWithRandomGen(randomGen RandomGenerator, config *Config) *Backoff {
+	return newBackoff(randomGen, SystemClock{}, config)
+}
+
+// newBackoff creates a Backoff using the defaultClock, unless the config specifies its own Clock.
+func newBackoff(randomGen RandomGenerator, defaultClock Clock, config *Config) *Backoff {
 	if config.Base == 0 {
 		config.Base = DefaultConfig.Base
 	}
@@ -93,20 +122,107 @@ func NewBackoffWithRandomGen(randomGen RandomGenerator, config *Config) *Backoff
 		config.Jitter = DefaultConfig.Jitter
 	}
 
+	if config.Strategy == nil {
+		config.Strategy = DefaultConfig.Strategy
+	}
+
+	clock := config.Clock
+	if clock == nil {
+		clock = defaultClock
+	}
+
 	return &Backoff{
 		config:    config,
 		randomGen: randomGen,
+		clock:     clock,
+		strategy:  config.Strategy(randomGen, config),
+		startTime: clock.Now(),
 	}
 }
 
 // Next returns the next duration for the retry.
+//
+// It returns Stop when Config.MaxAttempts or Config.MaxElapsedTime is exceeded.
 func (b *Backoff) Next() time.Duration {
-	d := b.config.Base * (1 << b.retryCount)
-	if d > b.config.Max {
-		d = b.config.Max
-	} else {
-		b.retryCount++
+	if b.config.MaxAttempts > 0 && b.attempt >= b.config.MaxAttempts {
+		return Stop
+	}
+
+	step := b.nextStep()
+	if b.config.MaxElapsedTime > 0 && b.clock.Now().Sub(b.startTime)+step.Delay > b.config.MaxElapsedTime {
+		return Stop
+	}
+
+	b.attempt++
+
+	if b.config.Observer != nil {
+		b.config.Observer.ObserveBackoff(Event{
+			Attempt:  b.attempt,
+			RawDelay: step.Raw,
+			Delay:    step.Delay,
+			Capped:   step.Capped,
+		})
+	}
+
+	return step.Delay
+}
+
+func (b *Backoff) nextStep() Step {
+	stepStrategy, ok := b.strategy.(StepStrategy)
+	if ok {
+		return stepStrategy.NextStep()
 	}
 
-	return b.config.Jitter(b.randomGen, int64(d))
+	d := b.strategy.Next()
+
+	return Step{
+		Raw:    d,
+		Delay:  d,
+		Capped: false,
+	}
+}
+
+// Attempt returns the number of durations returned by Next since the backoff creation or the last
+// Reset call.
+func (b *Backoff) Attempt() int {
+	return b.attempt
+}
+
+// Reset brings the backoff to its initial state, so it can be reused for a new series of retries.
+func (b *Backoff) Reset() {
+	b.strategy = b.config.Strategy(b.randomGen, b.config)
+	b.attempt = 0
+	b.startTime = b.clock.Now()
+}
+
+// Wait sleeps for the next duration returned by Next.
+//
+// It returns ErrStopped when Next returns Stop, and the context error when the context is done
+// before the sleep is over.
+func (b *Backoff) Wait(ctx context.Context) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	d := b.Next()
+	if d == Stop {
+		return ErrStopped
+	}
+
+	return b.Sleep(ctx, d)
+}
+
+// Sleep sleeps for the duration d using the backoff clock.
+//
+// It returns the context error when the context is done before the sleep is over.
+func (b *Backoff) Sleep(ctx context.Context, d time.Duration) error {
+	timer := b.clock.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C():
+		return nil
+	}
 }
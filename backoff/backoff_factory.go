@@ -28,6 +28,7 @@ import (
 //		}
 type BackoffFactory struct {
 	randomGen RandomGenerator
+	clock     Clock
 }
 
 // NewBackoffFactory creates a BackoffFactory instance using a standard random generator.
@@ -35,15 +36,23 @@ type BackoffFactory struct {
 // The shared random generator is seeded with the current time in nanoseconds, and wrapped with
 // SyncRandomGenerator for multi go routine safety.
 func NewBackoffFactory() *BackoffFactory {
+	return NewBackoffFactoryWithClock(SystemClock{})
+}
+
+// NewBackoffFactoryWithClock creates a BackoffFactory instance, whose backoff objects use
+// the provided Clock, unless their Config specifies its own.
+func NewBackoffFactoryWithClock(clock Clock) *BackoffFactory {
 	return &BackoffFactory{
 		randomGen: NewSyncRandomGenerator(rand.New(rand.NewSource(time.Now().UnixNano()))), //nolint: gosec
+		clock:     clock,
 	}
 }
 
 // CreateBackoff returns a Backoff instance using a shared RandomGenerator.
 func (bf *BackoffFactory) CreateBackoff(config *Config) *Backoff {
-	return NewBackoffWithRandomGen(
+	return newBackoff(
 		bf.randomGen,
+		bf.clock,
 		config,
 	)
 }
@@ -1,9 +1,11 @@
 package backoff_test
 
 import (
+	"context"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	backoff "github.com/sumup-oss/go-pkgs/backoff"
+	"github.com/sumup-oss/go-pkgs/backoff/backofftest"
 	"testing"
 	"time"
 )
@@ -83,3 +85,299 @@ func TestNewBackOff(t *testing.T) {
 		},
 	)
 }
+
+func TestBackoffStrategies(t *testing.T) {
+	t.Run(
+		"check backoff implementation using exponential strategy without jitter",
+		func(t *testing.T) {
+			t.Parallel()
+
+			exponential := backoff.NewBackoffWithRandomGen(
+				&RandomGeneratorMock{},
+				&backoff.Config{
+					Base:     time.Second,
+					Max:      time.Second * 5,
+					Jitter:   backoff.NoJitter,
+					Strategy: backoff.ExponentialStrategy,
+				},
+			)
+
+			assert.Equal(t, time.Second, exponential.Next())
+			assert.Equal(t, time.Second*2, exponential.Next())
+			assert.Equal(t, time.Second*4, exponential.Next())
+			assert.Equal(t, time.Second*5, exponential.Next())
+			assert.Equal(t, time.Second*5, exponential.Next())
+		},
+	)
+
+	t.Run(
+		"check backoff implementation using decorrelated jitter strategy",
+		func(t *testing.T) {
+			t.Parallel()
+			randomGeneratorMock := &RandomGeneratorMock{}
+
+			// rand(Base, Base*3)
+			randomGeneratorMock.On("Int63n", int64(2*time.Second+1)).Return(int64(time.Second)).Once()
+			// rand(Base, 2s*3)
+			randomGeneratorMock.On("Int63n", int64(5*time.Second+1)).Return(int64(4 * time.Second)).Once()
+			// rand(Base, min(Max, 5s*3))
+			randomGeneratorMock.On("Int63n", int64(9*time.Second+1)).Return(int64(9 * time.Second)).Once()
+
+			decorrelated := backoff.NewBackoffWithRandomGen(
+				randomGeneratorMock,
+				&backoff.Config{
+					Base:     time.Second,
+					Max:      time.Second * 10,
+					Strategy: backoff.DecorrelatedJitterStrategy,
+				},
+			)
+
+			assert.Equal(t, time.Second*2, decorrelated.Next())
+			assert.Equal(t, time.Second*5, decorrelated.Next())
+			assert.Equal(t, time.Second*10, decorrelated.Next())
+			randomGeneratorMock.AssertExpectations(t)
+		},
+	)
+}
+
+func TestBackoff_Lifecycle(t *testing.T) {
+	t.Run("it returns Stop when max attempts are exceeded", func(t *testing.T) {
+		t.Parallel()
+
+		b := backoff.NewBackoff(
+			&backoff.Config{
+				Base:        time.Second,
+				Jitter:      backoff.NoJitter,
+				MaxAttempts: 2,
+			},
+		)
+
+		assert.Equal(t, time.Second, b.Next())
+		assert.Equal(t, time.Second*2, b.Next())
+		assert.Equal(t, 2, b.Attempt())
+		assert.Equal(t, backoff.Stop, b.Next())
+		assert.Equal(t, 2, b.Attempt())
+	})
+
+	t.Run("it returns Stop when the next duration exceeds the max elapsed time", func(t *testing.T) {
+		t.Parallel()
+
+		b := backoff.NewBackoff(
+			&backoff.Config{
+				Base:           time.Minute,
+				Jitter:         backoff.NoJitter,
+				MaxElapsedTime: time.Second,
+			},
+		)
+
+		assert.Equal(t, backoff.Stop, b.Next())
+		assert.Equal(t, 0, b.Attempt())
+	})
+
+	t.Run("it starts over after reset", func(t *testing.T) {
+		t.Parallel()
+
+		b := backoff.NewBackoff(
+			&backoff.Config{
+				Base:        time.Second,
+				Jitter:      backoff.NoJitter,
+				MaxAttempts: 2,
+			},
+		)
+
+		b.Next()
+		b.Next()
+		assert.Equal(t, backoff.Stop, b.Next())
+
+		b.Reset()
+
+		assert.Equal(t, 0, b.Attempt())
+		assert.Equal(t, time.Second, b.Next())
+		assert.Equal(t, 1, b.Attempt())
+	})
+}
+
+func TestBackoffPolicyStrategies(t *testing.T) {
+	newBackoff := func(strategy backoff.StrategyFactory) *backoff.Backoff {
+		return backoff.NewBackoffWithRandomGen(
+			&RandomGeneratorMock{},
+			&backoff.Config{
+				Base:     time.Second,
+				Max:      time.Second * 10,
+				Jitter:   backoff.NoJitter,
+				Strategy: strategy,
+			},
+		)
+	}
+
+	nextDurations := func(b *backoff.Backoff, count int) []time.Duration {
+		durations := make([]time.Duration, 0, count)
+		for i := 0; i < count; i++ {
+			durations = append(durations, b.Next())
+		}
+
+		return durations
+	}
+
+	t.Run("check backoff implementation using constant strategy", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(
+			t,
+			[]time.Duration{time.Second, time.Second, time.Second},
+			nextDurations(newBackoff(backoff.ConstantStrategy), 3),
+		)
+	})
+
+	t.Run("check backoff implementation using linear strategy", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(
+			t,
+			[]time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 4 * time.Second},
+			nextDurations(newBackoff(backoff.LinearStrategy), 4),
+		)
+	})
+
+	t.Run("check backoff implementation using fibonacci strategy", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(
+			t,
+			[]time.Duration{
+				time.Second, time.Second, 2 * time.Second, 3 * time.Second, 5 * time.Second, 8 * time.Second,
+				10 * time.Second, 10 * time.Second,
+			},
+			nextDurations(newBackoff(backoff.FibonacciStrategy), 8),
+		)
+	})
+
+	t.Run("check backoff implementation using polynomial strategy", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(
+			t,
+			[]time.Duration{time.Second, 4 * time.Second, 9 * time.Second, 10 * time.Second, 10 * time.Second},
+			nextDurations(newBackoff(backoff.PolynomialStrategy(2)), 5),
+		)
+	})
+
+	t.Run("check backoff implementation using linear strategy with Full Jitter", func(t *testing.T) {
+		t.Parallel()
+		randomGeneratorMock := &RandomGeneratorMock{}
+		randomGeneratorMock.On("Int63n", int64(time.Second)).Return(int64(100))
+		randomGeneratorMock.On("Int63n", int64(2*time.Second)).Return(int64(200))
+
+		linear := backoff.NewBackoffWithRandomGen(
+			randomGeneratorMock,
+			&backoff.Config{
+				Base:     time.Second,
+				Max:      time.Second * 10,
+				Jitter:   backoff.FullJitter,
+				Strategy: backoff.LinearStrategy,
+			},
+		)
+
+		assert.Equal(t, int64(101), linear.Next().Nanoseconds())
+		assert.Equal(t, int64(201), linear.Next().Nanoseconds())
+	})
+}
+
+func TestBackoff_Wait(t *testing.T) {
+	t.Run("it sleeps for the next durations until the backoff is stopped", func(t *testing.T) {
+		t.Parallel()
+
+		clock := backofftest.NewAutoAdvanceFakeClock(time.Now())
+		b := backoff.NewBackoff(
+			&backoff.Config{
+				Base:           time.Second,
+				Max:            time.Second * 30,
+				Jitter:         backoff.NoJitter,
+				MaxElapsedTime: time.Minute * 2,
+				Clock:          clock,
+			},
+		)
+
+		var err error
+		for err == nil {
+			err = b.Wait(context.Background())
+		}
+
+		assert.Equal(t, backoff.ErrStopped, err)
+		assert.Equal(
+			t,
+			[]time.Duration{
+				time.Second, time.Second * 2, time.Second * 4, time.Second * 8, time.Second * 16,
+				time.Second * 30, time.Second * 30,
+			},
+			clock.Sleeps(),
+		)
+	})
+
+	t.Run("when the context is canceled, it stops sleeping and returns the context error", func(t *testing.T) {
+		t.Parallel()
+
+		clock := backofftest.NewFakeClock(time.Now())
+		b := backoff.NewBackoffFactoryWithClock(clock).CreateBackoff(&backoff.Config{})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			clock.WaitForTimers(1)
+			cancel()
+		}()
+
+		err := b.Wait(ctx)
+		assert.Equal(t, context.Canceled, err)
+	})
+
+	t.Run("when the fake time is advanced, it stops sleeping", func(t *testing.T) {
+		t.Parallel()
+
+		clock := backofftest.NewFakeClock(time.Now())
+		b := backoff.NewBackoffFactoryWithClock(clock).CreateBackoff(
+			&backoff.Config{Base: time.Minute, Jitter: backoff.NoJitter},
+		)
+
+		go func() {
+			clock.WaitForTimers(1)
+			clock.Advance(time.Minute)
+		}()
+
+		err := b.Wait(context.Background())
+		assert.NoError(t, err)
+	})
+}
+
+func TestBackoff_Observer(t *testing.T) {
+	t.Run("it notifies the observer for every returned duration", func(t *testing.T) {
+		t.Parallel()
+
+		var events []backoff.Event
+		b := backoff.NewBackoff(
+			&backoff.Config{
+				Base:        time.Second,
+				Max:         time.Second * 3,
+				Jitter:      backoff.NoJitter,
+				MaxAttempts: 3,
+				Observer: backoff.ObserverFunc(func(event backoff.Event) {
+					events = append(events, event)
+				}),
+			},
+		)
+
+		b.Next()
+		b.Next()
+		b.Next()
+		b.Next()
+
+		assert.Equal(
+			t,
+			[]backoff.Event{
+				{Attempt: 1, RawDelay: time.Second, Delay: time.Second, Capped: false},
+				{Attempt: 2, RawDelay: time.Second * 2, Delay: time.Second * 2, Capped: false},
+				{Attempt: 3, RawDelay: time.Second * 4, Delay: time.Second * 3, Capped: true},
+			},
+			events,
+		)
+	})
+}
@@ -0,0 +1,163 @@
+package backofftest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sumup-oss/go-pkgs/backoff"
+)
+
+var _ backoff.Clock = (*FakeClock)(nil)
+
+// FakeClock is a backoff.Clock, whose time moves only when Advance is called.
+//
+// It records the durations of all created timers, so the exact backoff schedule can be verified
+// without actually sleeping.
+//
+// Example:
+//
+//	clock := backofftest.NewAutoAdvanceFakeClock(time.Now())
+//	b := backoff.NewBackoff(&backoff.Config{Clock: clock, Jitter: backoff.NoJitter})
+//
+//	_ = b.Wait(ctx)
+//	_ = b.Wait(ctx)
+//
+//	clock.Sleeps() // [1s 2s]
+type FakeClock struct {
+	mu          sync.Mutex
+	cond        *sync.Cond
+	now         time.Time
+	timers      []*fakeTimer
+	sleeps      []time.Duration
+	autoAdvance bool
+}
+
+// NewFakeClock creates a FakeClock, that fires its timers only when Advance is called.
+func NewFakeClock(now time.Time) *FakeClock {
+	clock := &FakeClock{
+		now: now,
+	}
+	clock.cond = sync.NewCond(&clock.mu)
+
+	return clock
+}
+
+// NewAutoAdvanceFakeClock creates a FakeClock, that advances its time and fires every timer as
+// soon as it is created.
+func NewAutoAdvanceFakeClock(now time.Time) *FakeClock {
+	clock := NewFakeClock(now)
+	clock.autoAdvance = true
+
+	return clock
+}
+
+// Now returns the fake current time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// NewTimer creates a timer that fires when the fake time reaches now+d.
+func (c *FakeClock) NewTimer(d time.Duration) backoff.Timer { //nolint:ireturn
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	timer := &fakeTimer{
+		clock:    c,
+		deadline: c.now.Add(d),
+		ch:       make(chan time.Time, 1),
+	}
+
+	c.sleeps = append(c.sleeps, d)
+
+	if c.autoAdvance {
+		if d > 0 {
+			c.now = c.now.Add(d)
+		}
+
+		timer.ch <- c.now
+	} else if d <= 0 {
+		timer.ch <- c.now
+	} else {
+		c.timers = append(c.timers, timer)
+	}
+
+	c.cond.Broadcast()
+
+	return timer
+}
+
+// Advance moves the fake time forward with d, firing all the timers that are due.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+
+	pending := c.timers[:0]
+	for _, timer := range c.timers {
+		if timer.deadline.After(c.now) {
+			pending = append(pending, timer)
+
+			continue
+		}
+
+		timer.ch <- c.now
+	}
+
+	c.timers = pending
+}
+
+// Sleeps returns the durations of all the timers created so far.
+func (c *FakeClock) Sleeps() []time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sleeps := make([]time.Duration, len(c.sleeps))
+	copy(sleeps, c.sleeps)
+
+	return sleeps
+}
+
+// WaitForTimers blocks until at least n timers were created since the FakeClock creation.
+//
+// It is useful for synchronizing with a goroutine before calling Advance.
+func (c *FakeClock) WaitForTimers(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for len(c.sleeps) < n {
+		c.cond.Wait()
+	}
+}
+
+func (c *FakeClock) stop(timer *fakeTimer) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, pending := range c.timers {
+		if pending == timer {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+
+			return true
+		}
+	}
+
+	return false
+}
+
+type fakeTimer struct {
+	clock    *FakeClock
+	deadline time.Time
+	ch       chan time.Time
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *fakeTimer) Stop() bool {
+	return t.clock.stop(t)
+}
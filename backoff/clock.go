@@ -0,0 +1,47 @@
+package backoff
+
+import "time"
+
+// Clock provides the time functionality used by the backoff.
+//
+// It is useful for testing, since the real time can be replaced with a fake one,
+// see backofftest.FakeClock.
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is the Clock counterpart of time.Timer.
+type Timer interface {
+	// C returns the channel on which the time is delivered when the timer fires.
+	C() <-chan time.Time
+	// Stop prevents the timer from firing, see time.Timer.Stop.
+	Stop() bool
+}
+
+// SystemClock is a Clock using the standard time package.
+type SystemClock struct{}
+
+// Now returns the current local time.
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// NewTimer creates a new time.Timer that fires after duration d.
+func (SystemClock) NewTimer(d time.Duration) Timer { //nolint:ireturn
+	return &systemTimer{
+		timer: time.NewTimer(d),
+	}
+}
+
+type systemTimer struct {
+	timer *time.Timer
+}
+
+func (t *systemTimer) C() <-chan time.Time {
+	return t.timer.C
+}
+
+func (t *systemTimer) Stop() bool {
+	return t.timer.Stop()
+}
@@ -0,0 +1,227 @@
+package backoff
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	keyBase           = "base"
+	keyMax            = "max"
+	keyJitter         = "jitter"
+	keyStrategy       = "strategy"
+	keyMaxAttempts    = "max_attempts"
+	keyMaxElapsedTime = "max_elapsed_time"
+)
+
+// Validate checks that the config values are consistent.
+//
+// Zero values are valid, since they are replaced with the DefaultConfig values.
+func (c *Config) Validate() error {
+	switch {
+	case c.Base < 0:
+		return fmt.Errorf("invalid backoff config: base %s must not be negative", c.Base)
+	case c.Max < 0:
+		return fmt.Errorf("invalid backoff config: max %s must not be negative", c.Max)
+	case c.Max != 0 && c.Base > c.Max:
+		return fmt.Errorf("invalid backoff config: base %s must not be greater than max %s", c.Base, c.Max)
+	case c.MaxAttempts < 0:
+		return fmt.Errorf("invalid backoff config: max_attempts %d must not be negative", c.MaxAttempts)
+	case c.MaxElapsedTime < 0:
+		return fmt.Errorf("invalid backoff config: max_elapsed_time %s must not be negative", c.MaxElapsedTime)
+	}
+
+	return nil
+}
+
+// UnmarshalText parses the config from comma separated key=value pairs, e.g.
+// "base=500ms,max=30s,jitter=equal,strategy=exponential,max_attempts=5,max_elapsed_time=5m".
+//
+// The jitter and strategy values are names registered with RegisterJitter and RegisterStrategy.
+// The keys that are not present keep their current values. The parsed config is validated.
+//
+// It makes the Config usable with flags, environment variables and text based config files.
+func (c *Config) UnmarshalText(text []byte) error {
+	values := make(map[string]string)
+
+	for _, pair := range strings.Split(string(text), ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return fmt.Errorf("invalid backoff config: %q is not a key=value pair", pair)
+		}
+
+		values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	return c.setValues(values)
+}
+
+// MarshalText returns the config in the format accepted by UnmarshalText.
+//
+// It fails when the Jitter or the Strategy is not registered.
+func (c *Config) MarshalText() ([]byte, error) {
+	values, err := c.values()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	for _, key := range []string{keyBase, keyMax, keyJitter, keyStrategy, keyMaxAttempts, keyMaxElapsedTime} {
+		value, ok := values[key]
+		if !ok {
+			continue
+		}
+
+		if buf.Len() > 0 {
+			buf.WriteByte(',')
+		}
+
+		buf.WriteString(key + "=" + value)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON parses the config either from a JSON string in the UnmarshalText format, or from
+// a JSON object with the same keys, e.g. {"base": "500ms", "max": "30s", "jitter": "equal"}.
+func (c *Config) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		return c.UnmarshalText([]byte(text))
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return fmt.Errorf("invalid backoff config: %w", err)
+	}
+
+	values := make(map[string]string, len(object))
+
+	for key, raw := range object {
+		var value interface{}
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return fmt.Errorf("invalid backoff config: %s: %w", key, err)
+		}
+
+		switch v := value.(type) {
+		case string:
+			values[key] = v
+		case float64:
+			values[key] = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			return fmt.Errorf("invalid backoff config: %s must be a string or a number", key)
+		}
+	}
+
+	return c.setValues(values)
+}
+
+// MarshalJSON returns the config as a JSON object in the format accepted by UnmarshalJSON.
+//
+// It fails when the Jitter or the Strategy is not registered.
+func (c *Config) MarshalJSON() ([]byte, error) {
+	values, err := c.values()
+	if err != nil {
+		return nil, err
+	}
+
+	object := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		object[key] = value
+	}
+
+	if c.MaxAttempts != 0 {
+		object[keyMaxAttempts] = c.MaxAttempts
+	}
+
+	return json.Marshal(object)
+}
+
+func (c *Config) setValues(values map[string]string) error {
+	parsed := *c
+
+	for key, value := range values {
+		var err error
+
+		switch key {
+		case keyBase:
+			parsed.Base, err = time.ParseDuration(value)
+		case keyMax:
+			parsed.Max, err = time.ParseDuration(value)
+		case keyJitter:
+			parsed.Jitter, err = lookupJitter(value)
+		case keyStrategy:
+			parsed.Strategy, err = lookupStrategy(value)
+		case keyMaxAttempts:
+			parsed.MaxAttempts, err = strconv.Atoi(value)
+		case keyMaxElapsedTime:
+			parsed.MaxElapsedTime, err = time.ParseDuration(value)
+		default:
+			err = errors.New("unknown key")
+		}
+
+		if err != nil {
+			return fmt.Errorf("invalid backoff config: %s: %w", key, err)
+		}
+	}
+
+	err := parsed.Validate()
+	if err != nil {
+		return err
+	}
+
+	*c = parsed
+
+	return nil
+}
+
+func (c *Config) values() (map[string]string, error) {
+	values := make(map[string]string)
+
+	if c.Base != 0 {
+		values[keyBase] = c.Base.String()
+	}
+
+	if c.Max != 0 {
+		values[keyMax] = c.Max.String()
+	}
+
+	if c.Jitter != nil {
+		name, err := jitterName(c.Jitter)
+		if err != nil {
+			return nil, fmt.Errorf("cannot marshal backoff config: %w", err)
+		}
+
+		values[keyJitter] = name
+	}
+
+	if c.Strategy != nil {
+		name, err := strategyName(c.Strategy)
+		if err != nil {
+			return nil, fmt.Errorf("cannot marshal backoff config: %w", err)
+		}
+
+		values[keyStrategy] = name
+	}
+
+	if c.MaxAttempts != 0 {
+		values[keyMaxAttempts] = strconv.Itoa(c.MaxAttempts)
+	}
+
+	if c.MaxElapsedTime != 0 {
+		values[keyMaxElapsedTime] = c.MaxElapsedTime.String()
+	}
+
+	return values, nil
+}
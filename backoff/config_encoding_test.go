@@ -0,0 +1,159 @@
+package backoff_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sumup-oss/go-pkgs/backoff"
+)
+
+func TestConfig_UnmarshalText(t *testing.T) {
+	t.Run("it parses all the keys", func(t *testing.T) {
+		t.Parallel()
+
+		var config backoff.Config
+		err := config.UnmarshalText(
+			[]byte("base=500ms, max=30s, jitter=none, strategy=linear, max_attempts=5, max_elapsed_time=1m"),
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, 500*time.Millisecond, config.Base)
+		assert.Equal(t, 30*time.Second, config.Max)
+		assert.Equal(t, 5, config.MaxAttempts)
+		assert.Equal(t, time.Minute, config.MaxElapsedTime)
+
+		b := backoff.NewBackoff(&config)
+		assert.Equal(t, 500*time.Millisecond, b.Next())
+		assert.Equal(t, time.Second, b.Next())
+	})
+
+	t.Run("it uses the registered names", func(t *testing.T) {
+		t.Parallel()
+
+		backoff.RegisterStrategy("test-quadratic", backoff.PolynomialStrategy(2))
+
+		var config backoff.Config
+		err := config.UnmarshalText([]byte("base=1s,max=1m,jitter=none,strategy=test-quadratic"))
+		require.NoError(t, err)
+
+		b := backoff.NewBackoff(&config)
+		assert.Equal(t, time.Second, b.Next())
+		assert.Equal(t, 4*time.Second, b.Next())
+	})
+
+	t.Run("when the values are invalid, it returns an error", func(t *testing.T) {
+		t.Parallel()
+
+		testCases := map[string]string{
+			"base":               `invalid backoff config: "base" is not a key=value pair`,
+			"base=foo":           `invalid backoff config: base: time: invalid duration "foo"`,
+			"jitter=foo":         `invalid backoff config: jitter: unknown jitter "foo", registered jitters are [equal full none]`,
+			"foo=bar":            "invalid backoff config: foo: unknown key",
+			"base=1m,max=1s":     "invalid backoff config: base 1m0s must not be greater than max 1s",
+			"max_attempts=-1":    "invalid backoff config: max_attempts -1 must not be negative",
+			"max_elapsed_time=x": `invalid backoff config: max_elapsed_time: time: invalid duration "x"`,
+		}
+
+		for text, expectedErr := range testCases {
+			config := backoff.Config{Base: time.Second}
+			err := config.UnmarshalText([]byte(text))
+			assert.EqualError(t, err, expectedErr, text)
+			assert.Equal(t, backoff.Config{Base: time.Second}, config, "config is modified on error")
+		}
+	})
+}
+
+func TestConfig_JSON(t *testing.T) {
+	t.Run("it parses a JSON object", func(t *testing.T) {
+		t.Parallel()
+
+		var config struct {
+			Backoff *backoff.Config `json:"backoff"`
+		}
+
+		err := json.Unmarshal(
+			[]byte(`{"backoff": {"base": "500ms", "max": "30s", "jitter": "equal", "max_attempts": 3}}`),
+			&config,
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, 500*time.Millisecond, config.Backoff.Base)
+		assert.Equal(t, 30*time.Second, config.Backoff.Max)
+		assert.Equal(t, 3, config.Backoff.MaxAttempts)
+		assert.NotNil(t, config.Backoff.Jitter)
+	})
+
+	t.Run("it parses a JSON string", func(t *testing.T) {
+		t.Parallel()
+
+		var config backoff.Config
+
+		err := json.Unmarshal([]byte(`"base=2s,strategy=constant"`), &config)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2*time.Second, config.Base)
+		assert.NotNil(t, config.Strategy)
+	})
+
+	t.Run("it marshals the config with registered names", func(t *testing.T) {
+		t.Parallel()
+
+		config := &backoff.Config{
+			Base:        time.Second,
+			Max:         time.Minute,
+			Jitter:      backoff.EqualJitter,
+			Strategy:    backoff.DecorrelatedJitterStrategy,
+			MaxAttempts: 3,
+		}
+
+		data, err := json.Marshal(config)
+		require.NoError(t, err)
+		assert.JSONEq(
+			t,
+			`{"base": "1s", "max": "1m0s", "jitter": "equal", "strategy": "decorrelated", "max_attempts": 3}`,
+			string(data),
+		)
+
+		text, err := config.MarshalText()
+		require.NoError(t, err)
+		assert.Equal(t, "base=1s,max=1m0s,jitter=equal,strategy=decorrelated,max_attempts=3", string(text))
+	})
+
+	t.Run("when the strategy is another closure of a registered factory, it fails to marshal", func(t *testing.T) {
+		t.Parallel()
+
+		cubic := backoff.PolynomialStrategy(3)
+		backoff.RegisterStrategy("test-cubic", cubic)
+
+		text, err := (&backoff.Config{Jitter: backoff.NoJitter, Strategy: cubic}).MarshalText()
+		require.NoError(t, err)
+		assert.Equal(t, "jitter=none,strategy=test-cubic", string(text))
+
+		_, err = (&backoff.Config{Jitter: backoff.NoJitter, Strategy: backoff.PolynomialStrategy(4)}).MarshalText()
+		assert.EqualError(
+			t,
+			err,
+			"cannot marshal backoff config: strategy is not registered, only the registered instance of a closure can be marshaled",
+		)
+
+		_, err = json.Marshal(&backoff.Config{Jitter: backoff.NoJitter, Strategy: backoff.PolynomialStrategy(3)})
+		assert.Error(t, err)
+	})
+
+	t.Run("when the jitter is not registered, it fails to marshal", func(t *testing.T) {
+		t.Parallel()
+
+		config := &backoff.Config{
+			Jitter: func(randomGen backoff.RandomGenerator, factor int64) time.Duration {
+				return 0
+			},
+		}
+
+		_, err := config.MarshalText()
+		assert.EqualError(t, err, "cannot marshal backoff config: jitter is not registered")
+	})
+}
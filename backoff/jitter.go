@@ -13,3 +13,8 @@ func EqualJitter(randomGen RandomGenerator, factor int64) time.Duration {
 
 	return time.Duration(half + randomGen.Int63n(half))
 }
+
+// NoJitter returns the factor as it is, without any randomization.
+func NoJitter(_ RandomGenerator, factor int64) time.Duration {
+	return time.Duration(factor)
+}
@@ -0,0 +1,32 @@
+package backoff
+
+import "time"
+
+// Event describes a duration returned by Backoff.Next.
+type Event struct {
+	// Attempt is the number of the returned duration, starting from 1 after the backoff creation or
+	// the last Reset call.
+	Attempt int
+	// RawDelay is the duration before applying the Max cap and the jitter.
+	RawDelay time.Duration
+	// Delay is the duration returned by Backoff.Next.
+	Delay time.Duration
+	// Capped is true when the RawDelay exceeded Max and was capped.
+	Capped bool
+}
+
+// Observer is notified for every duration returned by Backoff.Next, e.g. for metrics and logging.
+//
+// When the Strategy does not implement StepStrategy, RawDelay is the same as Delay and Capped
+// is false.
+type Observer interface {
+	ObserveBackoff(event Event)
+}
+
+// ObserverFunc adapts a function to the Observer interface.
+type ObserverFunc func(event Event)
+
+// ObserveBackoff calls f(event).
+func (f ObserverFunc) ObserveBackoff(event Event) {
+	f(event)
+}
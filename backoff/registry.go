@@ -0,0 +1,164 @@
+package backoff
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"unsafe"
+)
+
+// The registries map names to Jitter functions and strategies, so the Config can be parsed from
+// text or JSON, see Config.UnmarshalText.
+var (
+	registryMu sync.RWMutex
+	jitters    = map[string]Jitter{
+		"full":  FullJitter,
+		"equal": EqualJitter,
+		"none":  NoJitter,
+	}
+	strategies = map[string]StrategyFactory{
+		"exponential":  ExponentialStrategy,
+		"decorrelated": DecorrelatedJitterStrategy,
+		"constant":     ConstantStrategy,
+		"linear":       LinearStrategy,
+		"fibonacci":    FibonacciStrategy,
+	}
+)
+
+// RegisterJitter registers a Jitter under the name, so it can be used in parsed configs.
+//
+// Registering an already registered name replaces the previous Jitter.
+//
+// Example:
+//
+//	backoff.RegisterJitter("tenth", func(randomGen backoff.RandomGenerator, factor int64) time.Duration {
+//		return time.Duration(factor - randomGen.Int63n(factor/10+1))
+//	})
+func RegisterJitter(name string, jitter Jitter) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	jitters[name] = jitter
+}
+
+// RegisterStrategy registers a StrategyFactory under the name, so it can be used in parsed configs.
+//
+// Registering an already registered name replaces the previous StrategyFactory.
+//
+// Note that PolynomialStrategy is not registered by default, since it needs a degree. A config is
+// marshaled with the name only when its Strategy is the very instance registered, another
+// PolynomialStrategy with the same degree is not registered.
+//
+// Example:
+//
+//	backoff.RegisterStrategy("quadratic", backoff.PolynomialStrategy(2))
+func RegisterStrategy(name string, strategy StrategyFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	strategies[name] = strategy
+}
+
+func lookupJitter(name string) (Jitter, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	jitter, ok := jitters[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown jitter %q, registered jitters are %v", name, registeredNames(jitters))
+	}
+
+	return jitter, nil
+}
+
+func lookupStrategy(name string) (StrategyFactory, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	strategy, ok := strategies[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown strategy %q, registered strategies are %v", name, registeredNames(strategies))
+	}
+
+	return strategy, nil
+}
+
+// jitterName returns the registered name of the jitter.
+func jitterName(jitter Jitter) (string, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	return funcName(jitters, jitter, "jitter")
+}
+
+// strategyName returns the registered name of the strategy.
+func strategyName(strategy StrategyFactory) (string, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	return funcName(strategies, strategy, "strategy")
+}
+
+// funcName finds the name of fn in the registry.
+//
+// Functions can't be compared in Go, so the function values are compared by their pointers
+// instead. Every closure is a distinct value, even when created by the same function, e.g.
+// PolynomialStrategy(2) and PolynomialStrategy(3) share the code but not the value, so a closure
+// matches only the very same value that was registered. An error is returned when more than one
+// name matches.
+func funcName[T any](registry map[string]T, fn T, kind string) (string, error) {
+	pointer := reflect.ValueOf(fn).Pointer()
+	value := funcValue(fn)
+	sameCode := false
+
+	var names []string
+	for name, registered := range registry {
+		if reflect.ValueOf(registered).Pointer() != pointer {
+			continue
+		}
+
+		if funcValue(registered) != value {
+			sameCode = true
+
+			continue
+		}
+
+		names = append(names, name)
+	}
+
+	switch len(names) {
+	case 0:
+		if sameCode {
+			return "", fmt.Errorf(
+				"%s is not registered, only the registered instance of a closure can be marshaled",
+				kind,
+			)
+		}
+
+		return "", fmt.Errorf("%s is not registered", kind)
+	case 1:
+		return names[0], nil
+	default:
+		sort.Strings(names)
+
+		return "", fmt.Errorf("%s is registered under multiple names %v", kind, names)
+	}
+}
+
+// funcValue returns the pointer of the function value, which is distinct for every closure
+// instance, unlike the code pointer. T must be a func type.
+func funcValue[T any](fn T) unsafe.Pointer {
+	return *(*unsafe.Pointer)(unsafe.Pointer(&fn))
+}
+
+func registeredNames[T any](registry map[string]T) []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
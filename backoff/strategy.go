@@ -0,0 +1,285 @@
+package backoff
+
+import (
+	"math"
+	"time"
+)
+
+// Strategy calculates the backoff durations of a single Backoff instance.
+//
+// Unlike Jitter, a Strategy may keep state between the Next calls, e.g. the previously returned
+// duration. That is why the Config holds a StrategyFactory and every Backoff creates its own
+// Strategy.
+type Strategy interface {
+	// Next returns the next backoff duration.
+	Next() time.Duration
+}
+
+// Step describes how a backoff duration was calculated.
+type Step struct {
+	// Raw is the duration before applying the Max cap and the jitter.
+	Raw time.Duration
+	// Delay is the duration returned by the strategy.
+	Delay time.Duration
+	// Capped is true when the Raw duration exceeded Max and was capped.
+	Capped bool
+}
+
+// StepStrategy is a Strategy that can describe how its durations are calculated.
+//
+// It is used for reporting the calculation details to the Config.Observer. All the strategies
+// provided by this package implement it.
+type StepStrategy interface {
+	Strategy
+	// NextStep returns the next backoff duration along with the calculation details.
+	NextStep() Step
+}
+
+// StrategyFactory creates a Strategy for a Backoff instance.
+//
+// The randomGen and config are the ones the Backoff was created with.
+type StrategyFactory func(randomGen RandomGenerator, config *Config) Strategy
+
+// ExponentialStrategy creates a Strategy that returns Jitter(min(Max, Base*(2^retries))).
+//
+// This is the default strategy, it adapts the Config.Jitter functions, like FullJitter and
+// EqualJitter. Use NoJitter for plain exponential backoff.
+func ExponentialStrategy(randomGen RandomGenerator, config *Config) Strategy { //nolint:ireturn
+	return &exponentialStrategy{
+		config:    config,
+		randomGen: randomGen,
+	}
+}
+
+type exponentialStrategy struct {
+	config *Config
+
+	randomGen  RandomGenerator
+	retryCount uint
+}
+
+func (s *exponentialStrategy) Next() time.Duration {
+	return s.NextStep().Delay
+}
+
+func (s *exponentialStrategy) NextStep() Step {
+	raw := s.config.Base * (1 << s.retryCount)
+
+	d := raw
+	if d > s.config.Max {
+		d = s.config.Max
+	} else {
+		s.retryCount++
+	}
+
+	return jitterStep(s.config, s.randomGen, raw, d)
+}
+
+// DecorrelatedJitterStrategy creates a Strategy that returns min(Max, rand(Base, previous*3)).
+//
+// Check here for the details - https://aws.amazon.com/blogs/architecture/exponential-backoff-and-jitter/
+//
+// The durations are randomized by the strategy itself, so the Config.Jitter is not used.
+func DecorrelatedJitterStrategy(randomGen RandomGenerator, config *Config) Strategy { //nolint:ireturn
+	return &decorrelatedJitterStrategy{
+		config:    config,
+		randomGen: randomGen,
+		previous:  config.Base,
+	}
+}
+
+type decorrelatedJitterStrategy struct {
+	config *Config
+
+	randomGen RandomGenerator
+	previous  time.Duration
+}
+
+func (s *decorrelatedJitterStrategy) Next() time.Duration {
+	return s.NextStep().Delay
+}
+
+// NextStep returns the next duration, the Raw duration of the step is previous*3.
+func (s *decorrelatedJitterStrategy) NextStep() Step {
+	raw := s.previous * 3 //nolint:mnd
+	// raw < s.previous means that the multiplication overflowed.
+	if raw < s.previous {
+		raw = math.MaxInt64
+	}
+
+	upper := raw
+	if upper > s.config.Max {
+		upper = s.config.Max
+	}
+
+	d := s.config.Base
+	if upper > d {
+		d += time.Duration(s.randomGen.Int63n(int64(upper - d + 1)))
+	}
+
+	if d > s.config.Max {
+		d = s.config.Max
+	}
+
+	s.previous = d
+
+	return Step{
+		Raw:    raw,
+		Delay:  d,
+		Capped: raw > s.config.Max,
+	}
+}
+
+// ConstantStrategy creates a Strategy that returns Jitter(min(Max, Base)).
+func ConstantStrategy(randomGen RandomGenerator, config *Config) Strategy { //nolint:ireturn
+	return &constantStrategy{
+		config:    config,
+		randomGen: randomGen,
+	}
+}
+
+type constantStrategy struct {
+	config *Config
+
+	randomGen RandomGenerator
+}
+
+func (s *constantStrategy) Next() time.Duration {
+	return s.NextStep().Delay
+}
+
+func (s *constantStrategy) NextStep() Step {
+	d := s.config.Base
+	if d > s.config.Max {
+		d = s.config.Max
+	}
+
+	return jitterStep(s.config, s.randomGen, s.config.Base, d)
+}
+
+// LinearStrategy creates a Strategy that returns Jitter(min(Max, Base*(retries+1))).
+func LinearStrategy(randomGen RandomGenerator, config *Config) Strategy { //nolint:ireturn
+	return &linearStrategy{
+		config:    config,
+		randomGen: randomGen,
+	}
+}
+
+type linearStrategy struct {
+	config *Config
+
+	randomGen  RandomGenerator
+	retryCount time.Duration
+}
+
+func (s *linearStrategy) Next() time.Duration {
+	return s.NextStep().Delay
+}
+
+func (s *linearStrategy) NextStep() Step {
+	raw := s.config.Base * (s.retryCount + 1)
+
+	d := raw
+	if d > s.config.Max {
+		d = s.config.Max
+	} else {
+		s.retryCount++
+	}
+
+	return jitterStep(s.config, s.randomGen, raw, d)
+}
+
+// FibonacciStrategy creates a Strategy that returns Jitter(min(Max, Base*fib(retries+1))),
+// meaning Base, Base, 2*Base, 3*Base, 5*Base and so on.
+func FibonacciStrategy(randomGen RandomGenerator, config *Config) Strategy { //nolint:ireturn
+	return &fibonacciStrategy{
+		config:    config,
+		randomGen: randomGen,
+		previous:  0,
+		current:   config.Base,
+	}
+}
+
+type fibonacciStrategy struct {
+	config *Config
+
+	randomGen RandomGenerator
+	previous  time.Duration
+	current   time.Duration
+}
+
+func (s *fibonacciStrategy) Next() time.Duration {
+	return s.NextStep().Delay
+}
+
+func (s *fibonacciStrategy) NextStep() Step {
+	raw := s.current
+
+	d := raw
+	if d > s.config.Max {
+		d = s.config.Max
+	} else {
+		s.previous, s.current = s.current, s.previous+s.current
+	}
+
+	return jitterStep(s.config, s.randomGen, raw, d)
+}
+
+// PolynomialStrategy returns a StrategyFactory for strategies that return
+// Jitter(min(Max, Base*(retries+1)^degree)).
+//
+// Example:
+//
+//	b := backoff.NewBackoff(&backoff.Config{
+//		Base:     time.Second,
+//		Max:      time.Minute,
+//		Strategy: backoff.PolynomialStrategy(2),
+//	})
+func PolynomialStrategy(degree float64) StrategyFactory {
+	return func(randomGen RandomGenerator, config *Config) Strategy { //nolint:ireturn
+		return &polynomialStrategy{
+			config:    config,
+			randomGen: randomGen,
+			degree:    degree,
+		}
+	}
+}
+
+type polynomialStrategy struct {
+	config *Config
+
+	randomGen  RandomGenerator
+	degree     float64
+	retryCount float64
+}
+
+func (s *polynomialStrategy) Next() time.Duration {
+	return s.NextStep().Delay
+}
+
+func (s *polynomialStrategy) NextStep() Step {
+	// The calculation is done with floats, so the overflow results into +Inf instead of wrapping.
+	factor := float64(s.config.Base) * math.Pow(s.retryCount+1, s.degree)
+
+	raw := time.Duration(math.MaxInt64)
+	if factor < math.MaxInt64 {
+		raw = time.Duration(factor)
+	}
+
+	d := s.config.Max
+	if factor <= float64(s.config.Max) {
+		d = raw
+		s.retryCount++
+	}
+
+	return jitterStep(s.config, s.randomGen, raw, d)
+}
+
+// jitterStep applies the config Jitter to the capped duration d.
+func jitterStep(config *Config, randomGen RandomGenerator, raw, d time.Duration) Step {
+	return Step{
+		Raw:    raw,
+		Delay:  config.Jitter(randomGen, int64(d)),
+		Capped: raw > config.Max,
+	}
+}
@@ -0,0 +1,331 @@
+// Copyright 2026 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sumup-oss/go-pkgs/backoff"
+	"github.com/sumup-oss/go-pkgs/task"
+)
+
+const (
+	defaultFailureThreshold = 5
+	defaultSuccessThreshold = 1
+)
+
+// State is the state of the circuit.
+type State int
+
+const (
+	// StateClosed lets all the calls through.
+	StateClosed State = iota
+	// StateOpen rejects all the calls with OpenError until the cool-down is over.
+	StateOpen
+	// StateHalfOpen lets a single probe call through. When it succeeds the circuit is closed,
+	// when it fails the circuit is opened again.
+	StateHalfOpen
+)
+
+// String returns the state name.
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(s))
+	}
+}
+
+// Config is used for the circuit breaker constructor.
+type Config struct {
+	// FailureThreshold is the number of consecutive failures after which the circuit is opened.
+	// Defaults to 5.
+	FailureThreshold int
+	// SuccessThreshold is the number of consecutive successful probes in half-open state after
+	// which the circuit is closed. Defaults to 1.
+	SuccessThreshold int
+	// BackoffConfig is used for calculating how long the circuit stays open. Every time the circuit
+	// is opened again without being closed in between, the next backoff duration is used.
+	// When the backoff returns backoff.Stop, BackoffConfig.Max is used.
+	// Defaults to backoff.DefaultConfig.
+	BackoffConfig *backoff.Config
+	// IsFailure decides if the error returned by the task counts as a failure.
+	// Defaults to every non-nil error.
+	IsFailure func(err error) bool
+	// OnStateChange is called on every state change, e.g. for logging or metrics.
+	// It is called without holding the circuit breaker lock.
+	OnStateChange func(from, to State)
+}
+
+// OpenError is returned when a call is rejected, because the circuit is open.
+type OpenError struct {
+	retryAfter time.Duration
+}
+
+// Error returns the error message.
+func (err *OpenError) Error() string {
+	return "circuit breaker is open"
+}
+
+// RetryAfter returns the remaining cool-down of the open circuit.
+//
+// It is zero when the circuit is half-open and the probe call is still running.
+func (err *OpenError) RetryAfter() time.Duration {
+	return err.retryAfter
+}
+
+// IsOpenError checks if the error is OpenError, or wraps it.
+func IsOpenError(err error) bool {
+	var openErr *OpenError
+
+	return errors.As(err, &openErr)
+}
+
+// CircuitBreaker stops calling a failing dependency for a cool-down period, so it can recover.
+//
+// It is safe to be used in multiple go routines, and a single instance is supposed to be shared by
+// all the callers of the same dependency.
+//
+// Example:
+//
+//	cb := circuitbreaker.NewCircuitBreaker(&circuitbreaker.Config{
+//		FailureThreshold: 3,
+//		BackoffConfig:    &backoff.Config{Base: time.Second, Max: time.Minute},
+//	})
+//
+//	fetch := task.NewTaskFunc(fetchFromVault, cb.Wrap)
+//
+//	err := fetch(ctx)
+//	if circuitbreaker.IsOpenError(err) {
+//		// the dependency is down, fail fast
+//	}
+type CircuitBreaker struct {
+	config *Config
+	clock  backoff.Clock
+
+	mu         sync.Mutex
+	state      State
+	generation uint64
+	failures   int
+	successes  int
+	probing    bool
+	openUntil  time.Time
+	backoff    *backoff.Backoff
+}
+
+// NewCircuitBreaker creates CircuitBreaker instance in closed state.
+func NewCircuitBreaker(config *Config) *CircuitBreaker {
+	if config.FailureThreshold == 0 {
+		config.FailureThreshold = defaultFailureThreshold
+	}
+
+	if config.SuccessThreshold == 0 {
+		config.SuccessThreshold = defaultSuccessThreshold
+	}
+
+	if config.BackoffConfig == nil {
+		config.BackoffConfig = backoff.DefaultConfig
+	}
+
+	if config.IsFailure == nil {
+		config.IsFailure = func(err error) bool {
+			return err != nil
+		}
+	}
+
+	var clock backoff.Clock = backoff.SystemClock{}
+	if config.BackoffConfig.Clock != nil {
+		clock = config.BackoffConfig.Clock
+	}
+
+	return &CircuitBreaker{
+		config:  config,
+		clock:   clock,
+		state:   StateClosed,
+		backoff: backoff.NewBackoff(config.BackoffConfig),
+	}
+}
+
+// State returns the current state of the circuit.
+func (cb *CircuitBreaker) State() State {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	return cb.currentState()
+}
+
+// Wrap decorates the fn, so it is called only when the circuit allows it.
+//
+// When the call is rejected, the returned TaskFunc returns OpenError without calling fn.
+// Wrap is a task.TaskFuncDecorator, so it can be passed to task.NewTaskFunc.
+//
+// When fn panics, the call is recorded as a failure and the panic is propagated.
+func (cb *CircuitBreaker) Wrap(fn task.TaskFunc) task.TaskFunc {
+	return func(ctx context.Context) (err error) {
+		generation, err := cb.before()
+		if err != nil {
+			return err
+		}
+
+		panicked := true
+
+		defer func() {
+			if panicked {
+				cb.after(generation, false, true)
+
+				return
+			}
+
+			// The failures caused by the caller canceling the call, do not tell anything about
+			// the dependency health.
+			canceled := err != nil && ctx.Err() != nil
+			cb.after(generation, canceled, !canceled && cb.config.IsFailure(err))
+		}()
+
+		err = fn(ctx)
+		panicked = false
+
+		return err
+	}
+}
+
+type transition struct {
+	from State
+	to   State
+}
+
+func (cb *CircuitBreaker) before() (uint64, error) {
+	cb.mu.Lock()
+
+	var transitions []transition
+	defer func() {
+		cb.mu.Unlock()
+		cb.notify(transitions)
+	}()
+
+	if cb.state == StateOpen {
+		now := cb.clock.Now()
+		if now.Before(cb.openUntil) {
+			return 0, &OpenError{retryAfter: cb.openUntil.Sub(now)}
+		}
+
+		transitions = append(transitions, cb.setState(StateHalfOpen))
+	}
+
+	if cb.state == StateHalfOpen {
+		if cb.probing {
+			return 0, &OpenError{retryAfter: 0}
+		}
+
+		cb.probing = true
+	}
+
+	return cb.generation, nil
+}
+
+func (cb *CircuitBreaker) after(generation uint64, canceled, failed bool) {
+	cb.mu.Lock()
+
+	var transitions []transition
+	defer func() {
+		cb.mu.Unlock()
+		cb.notify(transitions)
+	}()
+
+	// The state has changed while the call was running, so its result is outdated.
+	if generation != cb.generation {
+		return
+	}
+
+	switch cb.state {
+	case StateClosed:
+		switch {
+		case canceled:
+		case failed:
+			cb.failures++
+			if cb.failures >= cb.config.FailureThreshold {
+				transitions = append(transitions, cb.trip())
+			}
+		default:
+			cb.failures = 0
+		}
+	case StateHalfOpen:
+		cb.probing = false
+
+		switch {
+		case canceled:
+		case failed:
+			transitions = append(transitions, cb.trip())
+		default:
+			cb.successes++
+			if cb.successes >= cb.config.SuccessThreshold {
+				cb.backoff.Reset()
+				transitions = append(transitions, cb.setState(StateClosed))
+			}
+		}
+	case StateOpen:
+	}
+}
+
+// currentState returns the state, taking into account an open circuit which cool-down is over.
+func (cb *CircuitBreaker) currentState() State {
+	if cb.state == StateOpen && !cb.clock.Now().Before(cb.openUntil) {
+		return StateHalfOpen
+	}
+
+	return cb.state
+}
+
+func (cb *CircuitBreaker) trip() transition {
+	coolDown := cb.backoff.Next()
+	if coolDown == backoff.Stop {
+		coolDown = cb.config.BackoffConfig.Max
+	}
+
+	cb.openUntil = cb.clock.Now().Add(coolDown)
+
+	return cb.setState(StateOpen)
+}
+
+func (cb *CircuitBreaker) setState(state State) transition {
+	t := transition{from: cb.state, to: state}
+
+	cb.state = state
+	cb.generation++
+	cb.failures = 0
+	cb.successes = 0
+	cb.probing = false
+
+	return t
+}
+
+func (cb *CircuitBreaker) notify(transitions []transition) {
+	if cb.config.OnStateChange == nil {
+		return
+	}
+
+	for _, t := range transitions {
+		cb.config.OnStateChange(t.from, t.to)
+	}
+}
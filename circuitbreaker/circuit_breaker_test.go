@@ -0,0 +1,209 @@
+// Copyright 2026 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package circuitbreaker_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sumup-oss/go-pkgs/backoff"
+	"github.com/sumup-oss/go-pkgs/backoff/backofftest"
+	"github.com/sumup-oss/go-pkgs/circuitbreaker"
+)
+
+func newTestCircuitBreaker(clock backoff.Clock, onStateChange func(from, to circuitbreaker.State)) *circuitbreaker.CircuitBreaker {
+	return circuitbreaker.NewCircuitBreaker(&circuitbreaker.Config{
+		FailureThreshold: 2,
+		BackoffConfig: &backoff.Config{
+			Base:   time.Second,
+			Max:    time.Minute,
+			Jitter: backoff.NoJitter,
+			Clock:  clock,
+		},
+		OnStateChange: onStateChange,
+	})
+}
+
+func failingTask(ctx context.Context) error {
+	return assert.AnError
+}
+
+func succeedingTask(ctx context.Context) error {
+	return nil
+}
+
+func TestCircuitBreaker_Wrap(t *testing.T) {
+	t.Run("when the failure threshold is reached, it opens the circuit and rejects calls", func(t *testing.T) {
+		t.Parallel()
+
+		cb := newTestCircuitBreaker(backofftest.NewFakeClock(time.Now()), nil)
+
+		cnt := 0
+		fn := cb.Wrap(func(ctx context.Context) error {
+			cnt++
+			return assert.AnError
+		})
+
+		assert.Equal(t, assert.AnError, fn(context.Background()))
+		assert.Equal(t, circuitbreaker.StateClosed, cb.State())
+		assert.Equal(t, assert.AnError, fn(context.Background()))
+		assert.Equal(t, circuitbreaker.StateOpen, cb.State())
+
+		err := fn(context.Background())
+		require.True(t, circuitbreaker.IsOpenError(err))
+		assert.EqualError(t, err, "circuit breaker is open")
+		assert.Equal(t, time.Second, err.(*circuitbreaker.OpenError).RetryAfter())
+		assert.True(t, circuitbreaker.IsOpenError(fmt.Errorf("vault read: %w", err)))
+		assert.Equal(t, 2, cnt)
+	})
+
+	t.Run("when a success happens between failures, it keeps the circuit closed", func(t *testing.T) {
+		t.Parallel()
+
+		cb := newTestCircuitBreaker(backofftest.NewFakeClock(time.Now()), nil)
+
+		_ = cb.Wrap(failingTask)(context.Background())
+		_ = cb.Wrap(succeedingTask)(context.Background())
+		_ = cb.Wrap(failingTask)(context.Background())
+
+		assert.Equal(t, circuitbreaker.StateClosed, cb.State())
+	})
+
+	t.Run("when the caller cancels the call, it does not count it as a failure", func(t *testing.T) {
+		t.Parallel()
+
+		cb := newTestCircuitBreaker(backofftest.NewFakeClock(time.Now()), nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_ = cb.Wrap(failingTask)(ctx)
+		_ = cb.Wrap(failingTask)(ctx)
+
+		assert.Equal(t, circuitbreaker.StateClosed, cb.State())
+	})
+
+	t.Run(
+		"when the cool-down is over and the probe succeeds, it closes the circuit",
+		func(t *testing.T) {
+			t.Parallel()
+
+			clock := backofftest.NewFakeClock(time.Now())
+
+			var transitions []string
+			cb := newTestCircuitBreaker(clock, func(from, to circuitbreaker.State) {
+				transitions = append(transitions, from.String()+"->"+to.String())
+			})
+
+			_ = cb.Wrap(failingTask)(context.Background())
+			_ = cb.Wrap(failingTask)(context.Background())
+
+			clock.Advance(time.Second)
+			assert.Equal(t, circuitbreaker.StateHalfOpen, cb.State())
+
+			err := cb.Wrap(succeedingTask)(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, circuitbreaker.StateClosed, cb.State())
+			assert.Equal(t, []string{"closed->open", "open->half-open", "half-open->closed"}, transitions)
+		},
+	)
+
+	t.Run(
+		"when the probe fails, it opens the circuit again for the next backoff duration",
+		func(t *testing.T) {
+			t.Parallel()
+
+			clock := backofftest.NewFakeClock(time.Now())
+			cb := newTestCircuitBreaker(clock, nil)
+
+			_ = cb.Wrap(failingTask)(context.Background())
+			_ = cb.Wrap(failingTask)(context.Background())
+
+			clock.Advance(time.Second)
+
+			err := cb.Wrap(failingTask)(context.Background())
+			assert.Equal(t, assert.AnError, err)
+			assert.Equal(t, circuitbreaker.StateOpen, cb.State())
+
+			err = cb.Wrap(succeedingTask)(context.Background())
+			require.True(t, circuitbreaker.IsOpenError(err))
+			assert.Equal(t, 2*time.Second, err.(*circuitbreaker.OpenError).RetryAfter())
+		},
+	)
+
+	t.Run("when the probe panics, it counts it as a failure and propagates the panic", func(t *testing.T) {
+		t.Parallel()
+
+		clock := backofftest.NewFakeClock(time.Now())
+		cb := newTestCircuitBreaker(clock, nil)
+
+		_ = cb.Wrap(failingTask)(context.Background())
+		_ = cb.Wrap(failingTask)(context.Background())
+
+		clock.Advance(time.Second)
+
+		assert.PanicsWithValue(t, "probe panic", func() {
+			_ = cb.Wrap(func(ctx context.Context) error {
+				panic("probe panic")
+			})(context.Background())
+		})
+		assert.Equal(t, circuitbreaker.StateOpen, cb.State())
+
+		clock.Advance(2 * time.Second)
+
+		err := cb.Wrap(succeedingTask)(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, circuitbreaker.StateClosed, cb.State())
+	})
+
+	t.Run("when the circuit is half-open, it lets only a single probe call through", func(t *testing.T) {
+		t.Parallel()
+
+		clock := backofftest.NewFakeClock(time.Now())
+		cb := newTestCircuitBreaker(clock, nil)
+
+		_ = cb.Wrap(failingTask)(context.Background())
+		_ = cb.Wrap(failingTask)(context.Background())
+
+		clock.Advance(time.Second)
+
+		probeRunning := make(chan struct{})
+		probeDone := make(chan struct{})
+		probeErr := make(chan error)
+
+		go func() {
+			probeErr <- cb.Wrap(func(ctx context.Context) error {
+				close(probeRunning)
+				<-probeDone
+
+				return nil
+			})(context.Background())
+		}()
+
+		<-probeRunning
+
+		err := cb.Wrap(succeedingTask)(context.Background())
+		assert.True(t, circuitbreaker.IsOpenError(err))
+
+		close(probeDone)
+		assert.NoError(t, <-probeErr)
+		assert.Equal(t, circuitbreaker.StateClosed, cb.State())
+	})
+}
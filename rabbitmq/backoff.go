@@ -0,0 +1,73 @@
+// Copyright 2019 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rabbitmq
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/sumup-oss/go-pkgs/backoff"
+	"github.com/sumup-oss/go-pkgs/logger"
+)
+
+// retryBackoffConfig returns a copy of the config, that makes the backoff return backoff.Stop
+// after maxRetryAttempts+1 retries, unless the config already specifies its own MaxAttempts.
+// The extra retry keeps the count of the retry loops, that checked the limit before counting
+// the retry.
+//
+// The default Max is filled in, so it can be used for calculations.
+//
+// The backoff schedule is logged, and passed to the config Observer if there is one.
+//
+// The copy is needed, since the passed config may be shared between clients.
+func retryBackoffConfig(
+	config *backoff.Config,
+	maxRetryAttempts int,
+	log logger.StructuredLogger,
+) *backoff.Config {
+	backoffConfig := *config
+	if backoffConfig.Max == 0 {
+		backoffConfig.Max = backoff.DefaultConfig.Max
+	}
+
+	if backoffConfig.MaxAttempts == 0 && maxRetryAttempts > 0 {
+		backoffConfig.MaxAttempts = maxRetryAttempts + 1
+	}
+
+	backoffConfig.Observer = &backoffLogger{
+		logger: log,
+		next:   config.Observer,
+	}
+
+	return &backoffConfig
+}
+
+// backoffLogger is a backoff.Observer logging the backoff schedule.
+type backoffLogger struct {
+	logger logger.StructuredLogger
+	next   backoff.Observer
+}
+
+func (o *backoffLogger) ObserveBackoff(event backoff.Event) {
+	o.logger.Info(
+		"backing off before retrying",
+		zap.Int("attempt", event.Attempt),
+		zap.Duration("delay", event.Delay),
+		zap.Bool("capped", event.Capped),
+	)
+
+	if o.next != nil {
+		o.next.ObserveBackoff(event)
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2026 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rabbitmq
+
+import (
+	"github.com/streadway/amqp"
+)
+
+// RecoverableErrorClassifier is a task.ErrorClassifier classifying *amqp.Error that can be
+// recovered from, e.g. by reconnecting, as retryable.
+//
+// Example:
+//
+//	err := task.RetryWithOptions(
+//		publish,
+//		task.WithClassifiers(rabbitmq.RecoverableErrorClassifier),
+//	)(ctx)
+func RecoverableErrorClassifier(err error) bool {
+	amqpErr, ok := err.(*amqp.Error)
+	if !ok {
+		return false
+	}
+
+	return amqpErr.Recover
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/sumup-oss/go-pkgs/backoff"
 	"github.com/sumup-oss/go-pkgs/logger"
+	"github.com/sumup-oss/go-pkgs/task"
 )
 
 type RetryableConsumer struct {
@@ -34,13 +35,18 @@ type RetryableConsumer struct {
 }
 
 type RetryableConsumerConfig struct {
+	// MaxRetryAttempts limits the consecutive retries to MaxRetryAttempts+1, zero means no limit.
+	// It is used only when BackoffConfig.MaxAttempts is not set.
 	MaxRetryAttempts int
 	// healthCheckFactor is a number representing how much N multiplied by backoffConfig.Max time is needed
 	// for a block of code to run w/o returning an error, to consider it healthy.
 	// E.g backConfig.Max = 1min, healthCheckFactor = 2, means that code needs to run 2min at least to be healthy
 	// and retried again starting from backoffConfig.Base the next time it has an error.
-	HealthCheckFactor  int
-	BackoffConfig      *backoff.Config
+	HealthCheckFactor int
+	BackoffConfig     *backoff.Config
+	// RetryBudget is consulted before every retry, when the budget is exhausted Run fails fast.
+	// It is optional and supposed to be shared between the clients of the same RabbitMQ cluster.
+	RetryBudget        task.RetryBudget
 	ConsumerConfig     ConsumerConfig
 	RabbitClientConfig *ClientConfig
 }
@@ -62,40 +68,38 @@ func NewRetryableConsumer(
 }
 
 func (c *RetryableConsumer) Run(ctx context.Context) error {
-	consumerBackoff := backoff.NewBackoff(c.config.BackoffConfig)
-	currentRetryAttempts := 0
+	backoffConfig := retryBackoffConfig(c.config.BackoffConfig, c.config.MaxRetryAttempts, c.logger)
 
-	for {
-		startTime := time.Now()
-		err := c.doRun(ctx)
-		if err != nil {
-			c.logger.Error("consumer run failed with error", zap.Error(err))
-
-			if c.config.MaxRetryAttempts != 0 && currentRetryAttempts > c.config.MaxRetryAttempts {
-				return stacktrace.NewError("retry attempts exceeded")
-			}
-
-			if time.Since(startTime) > time.Duration(c.config.HealthCheckFactor)*c.config.BackoffConfig.Max {
-				consumerBackoff = backoff.NewBackoff(c.config.BackoffConfig)
-				currentRetryAttempts = 0
-			} else {
-				currentRetryAttempts += 1
-			}
-
-			backoffDuration := consumerBackoff.Next()
+	healthyAfter := time.Duration(c.config.HealthCheckFactor) * backoffConfig.Max
+	if healthyAfter == 0 {
+		// Without HealthCheckFactor every run is considered healthy.
+		healthyAfter = time.Nanosecond
+	}
 
-			select {
-			case <-ctx.Done():
-				c.logger.Info("received context cancel")
+	supervisor := task.NewSupervisor(&task.SupervisorConfig{
+		BackoffConfig: backoffConfig,
+		HealthyAfter:  healthyAfter,
+		RetryBudget:   c.config.RetryBudget,
+		OnRestart: func(name string, err error) {
+			c.logger.Error("consumer run failed with error", zap.Error(err))
+		},
+	})
+	supervisor.Add("consumer", task.Transient, c.doRun)
 
-				return nil
-			case <-time.After(backoffDuration):
-				continue
-			}
+	err := supervisor.Run(ctx)
+	if err != nil {
+		if _, ok := err.(*task.RetryBudgetExceededError); ok {
+			return stacktrace.Propagate(err, "consumer retry budget exceeded")
 		}
 
-		return nil
+		return stacktrace.Propagate(err, "retry attempts exceeded")
 	}
+
+	if ctx.Err() != nil {
+		c.logger.Info("received context cancel")
+	}
+
+	return nil
 }
 
 func (c *RetryableConsumer) doRun(ctx context.Context) error {
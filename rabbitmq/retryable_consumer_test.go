@@ -0,0 +1,147 @@
+// Copyright 2026 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rabbitmq_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/palantir/stacktrace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sumup-oss/go-pkgs/backoff"
+	"github.com/sumup-oss/go-pkgs/backoff/backofftest"
+	"github.com/sumup-oss/go-pkgs/logger"
+	"github.com/sumup-oss/go-pkgs/rabbitmq"
+	"github.com/sumup-oss/go-pkgs/task"
+)
+
+type emptyRetryBudget struct{}
+
+func (emptyRetryBudget) Deposit() {}
+
+func (emptyRetryBudget) Withdraw() bool {
+	return false
+}
+
+func newTestRetryableConsumer(
+	clock backoff.Clock,
+	config rabbitmq.RetryableConsumerConfig,
+	clientFactory func(ctx context.Context, config *rabbitmq.ClientConfig) (rabbitmq.RabbitMQClientInterface, error),
+) *rabbitmq.RetryableConsumer {
+	config.BackoffConfig = &backoff.Config{
+		Base:   time.Second,
+		Max:    time.Minute,
+		Jitter: backoff.NoJitter,
+		Clock:  clock,
+	}
+
+	return rabbitmq.NewRetryableConsumer(clientFactory, config, logger.NewStructuredNopLogger("debug"), nil, nil)
+}
+
+func TestRetryableConsumer_Run(t *testing.T) {
+	t.Run("when the retry attempts are exceeded, it returns error", func(t *testing.T) {
+		t.Parallel()
+
+		calls := 0
+		consumer := newTestRetryableConsumer(
+			backofftest.NewAutoAdvanceFakeClock(time.Now()),
+			rabbitmq.RetryableConsumerConfig{MaxRetryAttempts: 2},
+			func(ctx context.Context, config *rabbitmq.ClientConfig) (rabbitmq.RabbitMQClientInterface, error) {
+				calls++
+
+				return nil, assert.AnError
+			},
+		)
+
+		err := consumer.Run(context.Background())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "retry attempts exceeded")
+		assert.Contains(t, err.Error(), assert.AnError.Error())
+		assert.Equal(t, 4, calls)
+	})
+
+	t.Run("when the retry budget is exhausted, it fails fast", func(t *testing.T) {
+		t.Parallel()
+
+		calls := 0
+		consumer := newTestRetryableConsumer(
+			backofftest.NewAutoAdvanceFakeClock(time.Now()),
+			rabbitmq.RetryableConsumerConfig{RetryBudget: emptyRetryBudget{}},
+			func(ctx context.Context, config *rabbitmq.ClientConfig) (rabbitmq.RabbitMQClientInterface, error) {
+				calls++
+
+				return nil, assert.AnError
+			},
+		)
+
+		err := consumer.Run(context.Background())
+
+		require.Error(t, err)
+		assert.IsType(t, (*task.RetryBudgetExceededError)(nil), stacktrace.RootCause(err))
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("when the context is canceled, it returns no error", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		consumer := newTestRetryableConsumer(
+			backofftest.NewAutoAdvanceFakeClock(time.Now()),
+			rabbitmq.RetryableConsumerConfig{MaxRetryAttempts: 5},
+			func(ctx context.Context, config *rabbitmq.ClientConfig) (rabbitmq.RabbitMQClientInterface, error) {
+				cancel()
+
+				return nil, assert.AnError
+			},
+		)
+
+		assert.NoError(t, consumer.Run(ctx))
+	})
+
+	t.Run("when a run was healthy, it restarts the backoff from the base", func(t *testing.T) {
+		t.Parallel()
+
+		clock := backofftest.NewAutoAdvanceFakeClock(time.Now())
+		ctx, cancel := context.WithCancel(context.Background())
+
+		calls := 0
+		consumer := newTestRetryableConsumer(
+			clock,
+			rabbitmq.RetryableConsumerConfig{HealthCheckFactor: 1},
+			func(ctx context.Context, config *rabbitmq.ClientConfig) (rabbitmq.RabbitMQClientInterface, error) {
+				calls++
+
+				switch calls {
+				case 3:
+					// the run takes HealthCheckFactor*Max, so it is healthy
+					clock.Advance(time.Minute)
+				case 5:
+					cancel()
+				}
+
+				return nil, assert.AnError
+			},
+		)
+
+		err := consumer.Run(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, time.Second, 2 * time.Second}, clock.Sleeps())
+	})
+}
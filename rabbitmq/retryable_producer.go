@@ -17,13 +17,13 @@ package rabbitmq
 import (
 	"context"
 	"sync"
-	"time"
 
 	"github.com/palantir/stacktrace"
 	"go.uber.org/zap"
 
 	"github.com/sumup-oss/go-pkgs/backoff"
 	"github.com/sumup-oss/go-pkgs/logger"
+	"github.com/sumup-oss/go-pkgs/task"
 )
 
 type RetryableProducer struct {
@@ -39,13 +39,19 @@ type RetryableProducer struct {
 }
 
 type RetryableProducerConfig struct {
+	// MaxRetryAttempts limits the consecutive retries to MaxRetryAttempts+1, zero means no limit.
+	// It is used only when BackoffConfig.MaxAttempts is not set.
 	MaxRetryAttempts int
 	// HealthCheckFactor is a number representing how much N multiplied by backoffConfig.Max time is needed
 	// for a block of code to run w/o returning an error, to consider it healthy.
 	// E.g backConfig.Max = 1min, healthCheckFactor = 2, means that code needs to run 2min at least to be healthy
 	// and retried again starting from backoffConfig.Base the next time it has an error.
-	HealthCheckFactor  int
-	BackoffConfig      *backoff.Config
+	HealthCheckFactor int
+	BackoffConfig     *backoff.Config
+	// RetryBudget is consulted before every retry, when the budget is exhausted the producer stops
+	// reconnecting. It is optional and supposed to be shared between the clients of the same RabbitMQ
+	// cluster.
+	RetryBudget        task.RetryBudget
 	RabbitClientConfig *ClientConfig
 }
 
@@ -118,27 +124,39 @@ func (p *RetryableProducer) newProducer(ctx context.Context) (*Producer, error)
 	return producer, nil
 }
 
-func (p *RetryableProducer) newProducerWithBackoff(ctx context.Context) (*Producer, error) {
-	producerBackoff := backoff.NewBackoff(p.config.BackoffConfig)
-	currentRetryAttempts := 0
+func (p *RetryableProducer) newProducerWithBackoff(
+	ctx context.Context,
+	producerBackoff *backoff.Backoff,
+) (*Producer, error) {
+	producerBackoff.Reset()
 
 	for {
 		producer, err := p.newProducer(ctx)
 		if err != nil {
 			p.logger.Error("producer connection failed with error", zap.Error(err))
 
-			if p.config.MaxRetryAttempts != 0 && currentRetryAttempts > p.config.MaxRetryAttempts {
-				return nil, stacktrace.NewError("retry attempts exceeded")
+			if p.config.RetryBudget != nil && !p.config.RetryBudget.Withdraw() {
+				return nil, stacktrace.Propagate(
+					task.NewRetryBudgetExceededError(err),
+					"producer retry budget exceeded",
+				)
 			}
 
-			backoffDuration := producerBackoff.Next()
+			err = producerBackoff.Wait(ctx)
+			if err == backoff.ErrStopped {
+				return nil, stacktrace.NewError("retry attempts exceeded")
+			}
 
-			select {
-			case <-ctx.Done():
+			if err != nil {
 				return nil, stacktrace.NewError("received context cancel")
-			case <-time.After(backoffDuration):
-				continue
 			}
+
+			continue
+		}
+
+		// A successful connection is a healthy call, so it refills the budget.
+		if p.config.RetryBudget != nil {
+			p.config.RetryBudget.Deposit()
 		}
 
 		return producer, nil
@@ -146,8 +164,10 @@ func (p *RetryableProducer) newProducerWithBackoff(ctx context.Context) (*Produc
 }
 
 func (p *RetryableProducer) initProducer(ctx context.Context) {
+	producerBackoff := backoff.NewBackoff(retryBackoffConfig(p.config.BackoffConfig, p.config.MaxRetryAttempts, p.logger))
+
 	for {
-		producer, err := p.newProducerWithBackoff(ctx)
+		producer, err := p.newProducerWithBackoff(ctx, producerBackoff)
 		if err != nil {
 			p.logger.Info("failed to create producer with backoff", zap.Error(err))
 
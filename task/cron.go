@@ -0,0 +1,293 @@
+// Copyright 2026 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronYearsLimit is how many years ahead CronSchedule.Next searches for a matching time.
+const cronYearsLimit = 5
+
+var (
+	cronMonthNames = map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}
+	cronWeekdayNames = map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}
+	cronDescriptors = map[string]string{
+		"@yearly":   "0 0 0 1 1 *",
+		"@annually": "0 0 0 1 1 *",
+		"@monthly":  "0 0 0 1 * *",
+		"@weekly":   "0 0 0 * * 0",
+		"@daily":    "0 0 0 * * *",
+		"@midnight": "0 0 0 * * *",
+		"@hourly":   "0 0 * * * *",
+	}
+)
+
+// CronSchedule is a parsed cron spec.
+type CronSchedule struct {
+	second   uint64
+	minute   uint64
+	hour     uint64
+	dom      uint64
+	month    uint64
+	dow      uint64
+	domStar  bool
+	dowStar  bool
+	location *time.Location
+}
+
+// ParseCron parses a cron spec.
+//
+// The spec has either 5 fields (minute, hour, day of month, month, day of week), or 6 fields
+// with seconds in front. Every field accepts "*", values, ranges "a-b", steps "*/n" or "a-b/n",
+// and comma separated lists of them. Months and days of week accept also names, e.g. "jan" or
+// "mon", and both 0 and 7 mean Sunday. When both day of month and day of week are restricted,
+// a time matches if either of them matches. Specs with days of month that never occur in
+// the months, like "0 0 30 2 *", are rejected.
+//
+// The descriptors @yearly, @annually, @monthly, @weekly, @daily, @midnight and @hourly are also
+// accepted.
+//
+// The spec can be prefixed with the time zone as "CRON_TZ=Europe/Berlin " or "TZ=Europe/Berlin ",
+// otherwise the time zone of the time passed to Next is used.
+func ParseCron(spec string) (*CronSchedule, error) {
+	schedule := &CronSchedule{}
+	fieldsSpec := strings.TrimSpace(spec)
+
+	if strings.HasPrefix(fieldsSpec, "CRON_TZ=") || strings.HasPrefix(fieldsSpec, "TZ=") {
+		tzSpec, rest, _ := strings.Cut(fieldsSpec, " ")
+		_, tz, _ := strings.Cut(tzSpec, "=")
+
+		location, err := time.LoadLocation(tz)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron spec %q: %w", spec, err)
+		}
+
+		schedule.location = location
+		fieldsSpec = strings.TrimSpace(rest)
+	}
+
+	if descriptor, ok := cronDescriptors[strings.ToLower(fieldsSpec)]; ok {
+		fieldsSpec = descriptor
+	}
+
+	fields := strings.Fields(fieldsSpec)
+
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("invalid cron spec %q: expected 5 or 6 fields, got %d", spec, len(fields))
+	}
+
+	var err error
+
+	parsers := []struct {
+		field              string
+		bits               *uint64
+		minValue, maxValue int
+		names              map[string]int
+	}{
+		{field: "second", bits: &schedule.second, minValue: 0, maxValue: 59},
+		{field: "minute", bits: &schedule.minute, minValue: 0, maxValue: 59},
+		{field: "hour", bits: &schedule.hour, minValue: 0, maxValue: 23},
+		{field: "day of month", bits: &schedule.dom, minValue: 1, maxValue: 31},
+		{field: "month", bits: &schedule.month, minValue: 1, maxValue: 12, names: cronMonthNames},
+		{field: "day of week", bits: &schedule.dow, minValue: 0, maxValue: 7, names: cronWeekdayNames},
+	}
+
+	for i, parser := range parsers {
+		*parser.bits, err = parseCronField(fields[i], parser.minValue, parser.maxValue, parser.names)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron spec %q: %s: %w", spec, parser.field, err)
+		}
+	}
+
+	// 7 is an alias of Sunday
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow |= 1
+		schedule.dow &^= 1 << 7
+	}
+
+	schedule.domStar = strings.HasPrefix(fields[3], "*")
+	schedule.dowStar = strings.HasPrefix(fields[5], "*")
+
+	if !schedule.monthDayOccurs() {
+		return nil, fmt.Errorf("invalid cron spec %q: the days of month never occur in the months", spec)
+	}
+
+	return schedule, nil
+}
+
+// monthDayOccurs reports if any of the days of month occurs in any of the months, e.g. it is false
+// for "0 0 30 2 *". When the day of week is restricted too, it matches on its own, so it is true.
+func (s *CronSchedule) monthDayOccurs() bool {
+	if !s.dowStar && !s.domStar {
+		return true
+	}
+
+	for month := time.January; month <= time.December; month++ {
+		if s.month&(1<<uint(month)) == 0 {
+			continue
+		}
+
+		// February has 29 days in the leap years.
+		days := time.Date(2024, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+		if s.dom&((1<<uint(days+1))-2) != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Next returns the first time matching the schedule, which is after t.
+//
+// It returns zero time, when there is no matching time within the next 5 years.
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	location := s.location
+	if location == nil {
+		location = t.Location()
+	}
+
+	t = t.In(location).Truncate(time.Second).Add(time.Second)
+	yearsLimit := t.Year() + cronYearsLimit
+
+	for t.Year() <= yearsLimit {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, location)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, location)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			// moving with absolute time, so the repeated hours of daylight saving time changes
+			// are not an endless loop
+			t = t.Add(time.Hour - time.Duration(t.Minute())*time.Minute - time.Duration(t.Second())*time.Second)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute - time.Duration(t.Second())*time.Second)
+		case s.second&(1<<uint(t.Second())) == 0:
+			t = t.Add(time.Second)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+func (s *CronSchedule) dayMatches(t time.Time) bool {
+	domMatches := s.dom&(1<<uint(t.Day())) != 0
+	dowMatches := s.dow&(1<<uint(t.Weekday())) != 0
+
+	if s.domStar || s.dowStar {
+		return domMatches && dowMatches
+	}
+
+	return domMatches || dowMatches
+}
+
+// parseCronField parses a comma separated list of cron ranges to a bit set of the matching values.
+func parseCronField(field string, minValue, maxValue int, names map[string]int) (uint64, error) {
+	var values uint64
+
+	for _, rangeSpec := range strings.Split(field, ",") {
+		rangeValues, err := parseCronRange(rangeSpec, minValue, maxValue, names)
+		if err != nil {
+			return 0, err
+		}
+
+		values |= rangeValues
+	}
+
+	return values, nil
+}
+
+func parseCronRange(rangeSpec string, minValue, maxValue int, names map[string]int) (uint64, error) {
+	rangeSpec, stepSpec, hasStep := strings.Cut(rangeSpec, "/")
+
+	start, end := minValue, maxValue
+
+	if rangeSpec != "*" {
+		startSpec, endSpec, isRange := strings.Cut(rangeSpec, "-")
+
+		var err error
+
+		start, err = parseCronValue(startSpec, minValue, maxValue, names)
+		if err != nil {
+			return 0, err
+		}
+
+		end = start
+
+		switch {
+		case isRange:
+			end, err = parseCronValue(endSpec, minValue, maxValue, names)
+			if err != nil {
+				return 0, err
+			}
+		case hasStep:
+			// "a/n" means from a to the max value with step n
+			end = maxValue
+		}
+
+		if end < start {
+			return 0, fmt.Errorf("range %q is reversed", rangeSpec)
+		}
+	}
+
+	step := 1
+
+	if hasStep {
+		var err error
+
+		step, err = strconv.Atoi(stepSpec)
+		if err != nil || step < 1 {
+			return 0, fmt.Errorf("invalid step %q", stepSpec)
+		}
+	}
+
+	var values uint64
+	for value := start; value <= end; value += step {
+		values |= 1 << uint(value)
+	}
+
+	return values, nil
+}
+
+func parseCronValue(valueSpec string, minValue, maxValue int, names map[string]int) (int, error) {
+	if value, ok := names[strings.ToLower(valueSpec)]; ok {
+		return value, nil
+	}
+
+	value, err := strconv.Atoi(valueSpec)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", valueSpec)
+	}
+
+	if value < minValue || value > maxValue {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", value, minValue, maxValue)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sumup-oss/go-pkgs/task"
+)
+
+func TestParseCron(t *testing.T) {
+	t.Run("when the spec is invalid, it returns error", func(t *testing.T) {
+		t.Parallel()
+
+		for _, spec := range []string{
+			"",
+			"* * * *",
+			"* * * * * * *",
+			"60 * * * *",
+			"* 24 * * *",
+			"* * 0 * *",
+			"* * * 13 *",
+			"* * * * 8",
+			"5-1 * * * *",
+			"*/0 * * * *",
+			"foo * * * *",
+			"CRON_TZ=Nowhere/Foo * * * * *",
+			"0 0 30 feb *",
+			"0 0 31 apr,jun,sep,nov *",
+		} {
+			_, err := task.ParseCron(spec)
+			assert.Error(t, err, spec)
+		}
+	})
+
+	t.Run("it returns the next matching times", func(t *testing.T) {
+		t.Parallel()
+
+		// Friday
+		now := time.Date(2026, 1, 2, 10, 0, 30, 0, time.UTC)
+
+		for spec, expected := range map[string]time.Time{
+			"* * * * *":            time.Date(2026, 1, 2, 10, 1, 0, 0, time.UTC),
+			"*/15 * * * * *":       time.Date(2026, 1, 2, 10, 0, 45, 0, time.UTC),
+			"30 4 * * *":           time.Date(2026, 1, 3, 4, 30, 0, 0, time.UTC),
+			"0 9-17/4 * * mon-fri": time.Date(2026, 1, 2, 13, 0, 0, 0, time.UTC),
+			"0 0 * * 7":            time.Date(2026, 1, 4, 0, 0, 0, 0, time.UTC),
+			"0 0 1,15 * *":         time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC),
+			"0 0 13 * fri":         time.Date(2026, 1, 9, 0, 0, 0, 0, time.UTC),
+			"0 0 29 feb *":         time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+			"@monthly":             time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+			"@hourly":              time.Date(2026, 1, 2, 11, 0, 0, 0, time.UTC),
+		} {
+			schedule, err := task.ParseCron(spec)
+			require.NoError(t, err, spec)
+
+			assert.Equal(t, expected, schedule.Next(now), spec)
+		}
+	})
+
+	t.Run("when the spec has a time zone, it matches the time in it", func(t *testing.T) {
+		t.Parallel()
+
+		schedule, err := task.ParseCron("CRON_TZ=Europe/Berlin 0 3 * * *")
+		require.NoError(t, err)
+
+		next := schedule.Next(time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC))
+
+		assert.Equal(t, time.Date(2026, 1, 3, 2, 0, 0, 0, time.UTC), next.UTC())
+	})
+
+	t.Run("when the daylight saving time changes, it does not skip or repeat the runs", func(t *testing.T) {
+		t.Parallel()
+
+		schedule, err := task.ParseCron("TZ=Europe/Berlin 30 * * * *")
+		require.NoError(t, err)
+
+		// the clocks go back from 03:00 CEST to 02:00 CET on 2026-10-25
+		next := time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC)
+
+		var runs []time.Time
+		for i := 0; i < 3; i++ {
+			next = schedule.Next(next)
+			runs = append(runs, next.UTC())
+		}
+
+		assert.Equal(
+			t,
+			[]time.Time{
+				time.Date(2026, 10, 25, 0, 30, 0, 0, time.UTC),
+				time.Date(2026, 10, 25, 1, 30, 0, 0, time.UTC),
+				time.Date(2026, 10, 25, 2, 30, 0, 0, time.UTC),
+			},
+			runs,
+		)
+	})
+
+	t.Run("when the day of month occurs only in leap years, it returns the leap day", func(t *testing.T) {
+		t.Parallel()
+
+		schedule, err := task.ParseCron("0 0 29 feb *")
+		require.NoError(t, err)
+
+		assert.Equal(
+			t,
+			time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC),
+			schedule.Next(time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)),
+		)
+	})
+
+	t.Run("when the day of week is restricted too, it accepts any day of month", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := task.ParseCron("0 0 30 feb mon")
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/sumup-oss/go-pkgs/logger"
+)
+
+// TaskMetric observes the runs of a task decorated with WithMetrics.
+type TaskMetric interface {
+	ObserveTaskStarted()
+	ObserveTaskFinished(duration time.Duration, err error)
+}
+
+// Timeout returns a TaskFuncDecorator that cancels the context passed to the task after
+// the timeout.
+//
+// The task is expected to return when its context is canceled, the decorator does not
+// abandon it.
+func Timeout(timeout time.Duration) TaskFuncDecorator {
+	return func(fn TaskFunc) TaskFunc {
+		return func(ctx context.Context) error {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			return fn(ctx)
+		}
+	}
+}
+
+// WithStructuredLogging returns a TaskFuncDecorator that logs when the task starts and finishes,
+// along with the duration and the error of the task.
+//
+// Use log.With to add fields identifying the task.
+func WithStructuredLogging(log logger.StructuredLogger) TaskFuncDecorator {
+	return func(fn TaskFunc) TaskFunc {
+		return func(ctx context.Context) error {
+			log.Debug("task started")
+
+			start := time.Now()
+			err := fn(ctx)
+			duration := time.Since(start)
+
+			if err != nil {
+				log.Error("task failed", zap.Duration("duration", duration), logger.ErrorField(err))
+
+				return err
+			}
+
+			log.Info("task finished", zap.Duration("duration", duration))
+
+			return nil
+		}
+	}
+}
+
+// WithMetrics returns a TaskFuncDecorator that reports every run of the task to the metric.
+func WithMetrics(metric TaskMetric) TaskFuncDecorator {
+	return func(fn TaskFunc) TaskFunc {
+		return func(ctx context.Context) error {
+			metric.ObserveTaskStarted()
+
+			start := time.Now()
+			err := fn(ctx)
+
+			metric.ObserveTaskFinished(time.Since(start), err)
+
+			return err
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/sumup-oss/go-pkgs/logger"
+	"github.com/sumup-oss/go-pkgs/task"
+)
+
+type testTaskMetric struct {
+	mu        sync.Mutex
+	started   int
+	durations []time.Duration
+	errs      []error
+}
+
+func (m *testTaskMetric) ObserveTaskStarted() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.started++
+}
+
+func (m *testTaskMetric) ObserveTaskFinished(duration time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.durations = append(m.durations, duration)
+	m.errs = append(m.errs, err)
+}
+
+func TestTimeout(t *testing.T) {
+	t.Run("it cancels the task context after the timeout", func(t *testing.T) {
+		t.Parallel()
+
+		fn := task.NewTaskFunc(
+			func(ctx context.Context) error {
+				<-ctx.Done()
+
+				return ctx.Err()
+			},
+			task.Timeout(time.Millisecond),
+		)
+
+		err := fn(context.Background())
+
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	})
+}
+
+func TestWithStructuredLogging(t *testing.T) {
+	t.Run("it logs the start and the finish of the task", func(t *testing.T) {
+		t.Parallel()
+
+		core, logs := observer.New(zapcore.DebugLevel)
+		log := &logger.ZapLogger{Logger: zap.New(core)}
+
+		fn := task.NewTaskFunc(
+			func(ctx context.Context) error {
+				return nil
+			},
+			task.WithStructuredLogging(log.With(zap.String("task", "foo"))),
+		)
+
+		require.NoError(t, fn(context.Background()))
+
+		entries := logs.AllUntimed()
+		require.Len(t, entries, 2)
+		assert.Equal(t, "task started", entries[0].Message)
+		assert.Equal(t, "task finished", entries[1].Message)
+		assert.Equal(t, "foo", entries[1].ContextMap()["task"])
+		assert.Contains(t, entries[1].ContextMap(), "duration")
+	})
+
+	t.Run("when the task fails, it logs the error", func(t *testing.T) {
+		t.Parallel()
+
+		core, logs := observer.New(zapcore.DebugLevel)
+		log := &logger.ZapLogger{Logger: zap.New(core)}
+
+		fn := task.NewTaskFunc(
+			func(ctx context.Context) error {
+				return assert.AnError
+			},
+			task.WithStructuredLogging(log),
+		)
+
+		assert.Equal(t, assert.AnError, fn(context.Background()))
+
+		entries := logs.FilterMessage("task failed").AllUntimed()
+		require.Len(t, entries, 1)
+		assert.Equal(t, zapcore.ErrorLevel, entries[0].Level)
+		assert.Equal(t, assert.AnError.Error(), entries[0].ContextMap()["error"])
+	})
+}
+
+func TestWithMetrics(t *testing.T) {
+	t.Run("it reports every run of the task", func(t *testing.T) {
+		t.Parallel()
+
+		metric := &testTaskMetric{}
+		fn := task.NewTaskFunc(
+			func(ctx context.Context) error {
+				return assert.AnError
+			},
+			task.WithMetrics(metric),
+		)
+
+		assert.Equal(t, assert.AnError, fn(context.Background()))
+		assert.Equal(t, assert.AnError, fn(context.Background()))
+
+		assert.Equal(t, 2, metric.started)
+		assert.Len(t, metric.durations, 2)
+		assert.Equal(t, []error{assert.AnError, assert.AnError}, metric.errs)
+	})
+}
@@ -0,0 +1,357 @@
+// Copyright 2026 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// NodeState is the state of a Graph task after the graph run.
+type NodeState int
+
+const (
+	// NodeSucceeded is the state of a task that returned no error.
+	NodeSucceeded NodeState = iota
+	// NodeFailed is the state of a task that returned an error.
+	NodeFailed
+	// NodeSkipped is the state of a task that did not run, because one of its dependencies did not
+	// succeed or the context was done.
+	NodeSkipped
+)
+
+// String returns the state name.
+func (s NodeState) String() string {
+	switch s {
+	case NodeSucceeded:
+		return "succeeded"
+	case NodeFailed:
+		return "failed"
+	case NodeSkipped:
+		return "skipped"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(s))
+	}
+}
+
+// NodeResult is the result of a Graph task.
+type NodeResult struct {
+	Name  string
+	State NodeState
+	// Err is the task error for failed tasks, and the context error for tasks skipped because
+	// the context was done.
+	Err error
+	// Duration is how long the task ran.
+	Duration time.Duration
+}
+
+// GraphError is returned by Graph.Run when some of the tasks failed.
+type GraphError struct {
+	// Failed are the results of the failed tasks in the order they were added to the graph.
+	Failed []*NodeResult
+}
+
+// Error returns the error message.
+func (err *GraphError) Error() string {
+	messages := make([]string, 0, len(err.Failed))
+	for _, result := range err.Failed {
+		messages = append(messages, fmt.Sprintf("task %q: %v", result.Name, result.Err))
+	}
+
+	return fmt.Sprintf("%d graph tasks failed: %s", len(err.Failed), strings.Join(messages, "; "))
+}
+
+// Unwrap returns the errors of the failed tasks.
+func (err *GraphError) Unwrap() []error {
+	errs := make([]error, 0, len(err.Failed))
+	for _, result := range err.Failed {
+		errs = append(errs, result.Err)
+	}
+
+	return errs
+}
+
+// GraphCycleError is returned when the graph tasks depend on each other in a cycle.
+type GraphCycleError struct {
+	// Cycle are the task names forming the cycle, starting and ending with the same task.
+	Cycle []string
+}
+
+// Error returns the error message.
+func (err *GraphCycleError) Error() string {
+	return "task graph has a cycle: " + strings.Join(err.Cycle, " -> ")
+}
+
+type graphNode struct {
+	name      string
+	fn        TaskFunc
+	dependsOn []string
+}
+
+// Graph runs tasks respecting the dependencies between them.
+//
+// Every task starts as soon as all its dependencies succeed, so the independent tasks run in
+// parallel. When a task fails, the tasks depending on it, directly or not, are skipped, while
+// the rest continue.
+//
+// Example:
+//
+//	graph := task.NewGraph()
+//	graph.Add("vault", vaultAuth)
+//	graph.Add("secrets", createSecrets, "vault")
+//	graph.Add("rabbitmq", setupRabbitMQ, "vault")
+//	graph.Add("helm", helmInstall, "secrets")
+//
+//	results, err := graph.Run(ctx)
+type Graph struct {
+	nodes []*graphNode
+	limit int
+}
+
+// NewGraph creates Graph instance.
+func NewGraph() *Graph {
+	return &Graph{}
+}
+
+// Add adds a task, that runs after the tasks it depends on succeed.
+//
+// The dependencies can be added later, they are checked when the graph is validated.
+func (g *Graph) Add(name string, fn TaskFunc, dependsOn ...string) {
+	g.nodes = append(g.nodes, &graphNode{
+		name:      name,
+		fn:        fn,
+		dependsOn: dependsOn,
+	})
+}
+
+// SetLimit limits the number of tasks running at the same time to at most n.
+// A negative or zero value means no limit.
+func (g *Graph) SetLimit(n int) {
+	g.limit = n
+}
+
+// Validate checks that the task names are unique, the dependencies exist and do not form
+// a cycle.
+//
+// It returns GraphCycleError when there is a cycle.
+func (g *Graph) Validate() error {
+	nodes := make(map[string]*graphNode, len(g.nodes))
+
+	for _, node := range g.nodes {
+		if _, ok := nodes[node.name]; ok {
+			return fmt.Errorf("task graph has duplicate task %q", node.name)
+		}
+
+		nodes[node.name] = node
+	}
+
+	for _, node := range g.nodes {
+		for _, dependency := range node.dependsOn {
+			if _, ok := nodes[dependency]; !ok {
+				return fmt.Errorf("task graph task %q depends on unknown task %q", node.name, dependency)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	states := make(map[string]int, len(g.nodes))
+	path := make([]string, 0, len(g.nodes))
+
+	var visit func(node *graphNode) []string
+
+	visit = func(node *graphNode) []string {
+		switch states[node.name] {
+		case visited:
+			return nil
+		case visiting:
+			for i, name := range path {
+				if name == node.name {
+					return append(append([]string{}, path[i:]...), node.name)
+				}
+			}
+		}
+
+		states[node.name] = visiting
+		path = append(path, node.name)
+
+		for _, dependency := range node.dependsOn {
+			cycle := visit(nodes[dependency])
+			if cycle != nil {
+				return cycle
+			}
+		}
+
+		path = path[:len(path)-1]
+		states[node.name] = visited
+
+		return nil
+	}
+
+	for _, node := range g.nodes {
+		cycle := visit(node)
+		if cycle != nil {
+			return &GraphCycleError{Cycle: cycle}
+		}
+	}
+
+	return nil
+}
+
+type graphNodeDone struct {
+	index    int
+	err      error
+	duration time.Duration
+}
+
+// Run validates the graph and runs the tasks.
+//
+// It returns the results of all the tasks by name. When some of the tasks failed, it returns
+// also GraphError. When the graph is not valid, no task is run and the validation error is
+// returned.
+//
+// When the context is done, the tasks not started yet are skipped, and the context error is
+// returned, unless some of the tasks failed.
+func (g *Graph) Run(ctx context.Context) (map[string]*NodeResult, error) {
+	err := g.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := make(map[string]int, len(g.nodes))
+	for i, node := range g.nodes {
+		indexes[node.name] = i
+	}
+
+	pendingDependencies := make([]int, len(g.nodes))
+	dependents := make([][]int, len(g.nodes))
+	results := make([]*NodeResult, len(g.nodes))
+
+	var ready []int
+
+	for i, node := range g.nodes {
+		pendingDependencies[i] = len(node.dependsOn)
+		if pendingDependencies[i] == 0 {
+			ready = append(ready, i)
+		}
+
+		for _, dependency := range node.dependsOn {
+			dependents[indexes[dependency]] = append(dependents[indexes[dependency]], i)
+		}
+	}
+
+	doneCh := make(chan graphNodeDone, len(g.nodes))
+	running := 0
+
+	for len(ready) > 0 || running > 0 {
+		for len(ready) > 0 && (g.limit <= 0 || running < g.limit) && ctx.Err() == nil {
+			index := ready[0]
+			ready = ready[1:]
+			running++
+
+			go func(index int) {
+				start := time.Now()
+				err := g.nodes[index].fn(ctx)
+				doneCh <- graphNodeDone{index: index, err: err, duration: time.Since(start)}
+			}(index)
+		}
+
+		if running == 0 {
+			break
+		}
+
+		done := <-doneCh
+		running--
+
+		result := &NodeResult{
+			Name:     g.nodes[done.index].name,
+			State:    NodeSucceeded,
+			Err:      done.err,
+			Duration: done.duration,
+		}
+		results[done.index] = result
+
+		if done.err != nil {
+			result.State = NodeFailed
+			g.skipDependents(done.index, dependents, results)
+
+			continue
+		}
+
+		for _, dependent := range dependents[done.index] {
+			pendingDependencies[dependent]--
+			if pendingDependencies[dependent] == 0 && results[dependent] == nil {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	resultsByName := make(map[string]*NodeResult, len(g.nodes))
+
+	var (
+		failed   []*NodeResult
+		canceled bool
+	)
+
+	for i, node := range g.nodes {
+		result := results[i]
+		if result == nil {
+			result = &NodeResult{
+				Name:  node.name,
+				State: NodeSkipped,
+				Err:   ctx.Err(),
+			}
+			canceled = true
+		}
+
+		if result.State == NodeFailed {
+			failed = append(failed, result)
+		}
+
+		resultsByName[node.name] = result
+	}
+
+	if len(failed) > 0 {
+		return resultsByName, &GraphError{Failed: failed}
+	}
+
+	if canceled {
+		return resultsByName, ctx.Err()
+	}
+
+	return resultsByName, nil
+}
+
+// skipDependents marks the tasks depending on the failed task, directly or not, as skipped.
+func (g *Graph) skipDependents(index int, dependents [][]int, results []*NodeResult) {
+	for _, dependent := range dependents[index] {
+		if results[dependent] != nil {
+			continue
+		}
+
+		results[dependent] = &NodeResult{
+			Name:  g.nodes[dependent].name,
+			State: NodeSkipped,
+		}
+
+		g.skipDependents(dependent, dependents, results)
+	}
+}
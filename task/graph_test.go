@@ -0,0 +1,224 @@
+// Copyright 2026 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sumup-oss/go-pkgs/task"
+)
+
+func TestGraph(t *testing.T) {
+	t.Run("it runs the tasks after their dependencies and the independent tasks in parallel", func(t *testing.T) {
+		t.Parallel()
+
+		var mu sync.Mutex
+
+		var order []string
+
+		record := func(name string) task.TaskFunc {
+			return func(ctx context.Context) error {
+				mu.Lock()
+				defer mu.Unlock()
+
+				order = append(order, name)
+
+				return nil
+			}
+		}
+
+		// secrets and rabbitmq can only finish when both of them are running
+		barrier := &sync.WaitGroup{}
+		barrier.Add(2)
+		parallel := func(name string) task.TaskFunc {
+			return func(ctx context.Context) error {
+				barrier.Done()
+				barrier.Wait()
+
+				return record(name)(ctx)
+			}
+		}
+
+		graph := task.NewGraph()
+		graph.Add("helm", record("helm"), "secrets")
+		graph.Add("secrets", parallel("secrets"), "vault")
+		graph.Add("rabbitmq", parallel("rabbitmq"), "vault")
+		graph.Add("vault", record("vault"))
+
+		results, err := graph.Run(context.Background())
+
+		require.NoError(t, err)
+		require.Len(t, order, 4)
+		assert.Equal(t, "vault", order[0])
+		assert.Less(t, slices.Index(order, "secrets"), slices.Index(order, "helm"))
+
+		for _, result := range results {
+			assert.Equal(t, task.NodeSucceeded, result.State, result.Name)
+		}
+	})
+
+	t.Run("when a task fails, it skips the tasks depending on it", func(t *testing.T) {
+		t.Parallel()
+
+		noop := func(ctx context.Context) error {
+			return nil
+		}
+
+		graph := task.NewGraph()
+		graph.Add("vault", noop)
+		graph.Add("secrets", func(ctx context.Context) error {
+			return assert.AnError
+		}, "vault")
+		graph.Add("helm", noop, "secrets")
+		graph.Add("smoke", noop, "helm", "rabbitmq")
+		graph.Add("rabbitmq", noop, "vault")
+
+		results, err := graph.Run(context.Background())
+
+		var graphErr *task.GraphError
+		require.True(t, errors.As(err, &graphErr))
+		assert.True(t, errors.Is(err, assert.AnError))
+		assert.EqualError(t, err, `1 graph tasks failed: task "secrets": `+assert.AnError.Error())
+
+		assert.Equal(t, task.NodeSucceeded, results["vault"].State)
+		assert.Equal(t, task.NodeFailed, results["secrets"].State)
+		assert.Equal(t, assert.AnError, results["secrets"].Err)
+		assert.Equal(t, task.NodeSkipped, results["helm"].State)
+		assert.Equal(t, task.NodeSkipped, results["smoke"].State)
+		assert.Equal(t, task.NodeSucceeded, results["rabbitmq"].State)
+	})
+
+	t.Run("when the limit is set, it runs at most limit tasks at the same time", func(t *testing.T) {
+		t.Parallel()
+
+		var mu sync.Mutex
+
+		running, maxRunning := 0, 0
+		fn := func(ctx context.Context) error {
+			mu.Lock()
+			running++
+			maxRunning = max(maxRunning, running)
+			mu.Unlock()
+
+			mu.Lock()
+			running--
+			mu.Unlock()
+
+			return nil
+		}
+
+		graph := task.NewGraph()
+		graph.SetLimit(1)
+
+		for _, name := range []string{"a", "b", "c", "d"} {
+			graph.Add(name, fn)
+		}
+
+		_, err := graph.Run(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, maxRunning)
+	})
+
+	t.Run("when the context is done, it skips the tasks not started", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		graph := task.NewGraph()
+		graph.Add("vault", func(ctx context.Context) error {
+			cancel()
+
+			return nil
+		})
+		graph.Add("secrets", func(ctx context.Context) error {
+			return nil
+		}, "vault")
+
+		results, err := graph.Run(ctx)
+
+		assert.Equal(t, context.Canceled, err)
+		assert.Equal(t, task.NodeSucceeded, results["vault"].State)
+		assert.Equal(t, task.NodeSkipped, results["secrets"].State)
+		assert.Equal(t, context.Canceled, results["secrets"].Err)
+	})
+
+	t.Run("when the context is already done, it skips all the tasks and returns the context error", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		graph := task.NewGraph()
+		graph.Add("vault", func(ctx context.Context) error {
+			return nil
+		})
+
+		results, err := graph.Run(ctx)
+
+		assert.Equal(t, context.Canceled, err)
+		assert.Equal(t, task.NodeSkipped, results["vault"].State)
+	})
+
+	t.Run("when the tasks depend on each other in a cycle, it does not run any task", func(t *testing.T) {
+		t.Parallel()
+
+		ran := false
+		fn := func(ctx context.Context) error {
+			ran = true
+
+			return nil
+		}
+
+		graph := task.NewGraph()
+		graph.Add("vault", fn)
+		graph.Add("secrets", fn, "vault", "helm")
+		graph.Add("helm", fn, "rabbitmq")
+		graph.Add("rabbitmq", fn, "secrets")
+
+		_, err := graph.Run(context.Background())
+
+		var cycleErr *task.GraphCycleError
+		require.True(t, errors.As(err, &cycleErr))
+		assert.EqualError(t, err, "task graph has a cycle: secrets -> helm -> rabbitmq -> secrets")
+		assert.False(t, ran)
+	})
+
+	t.Run("when a dependency is unknown or a task is duplicate, it returns error", func(t *testing.T) {
+		t.Parallel()
+
+		fn := func(ctx context.Context) error {
+			return nil
+		}
+
+		graph := task.NewGraph()
+		graph.Add("secrets", fn, "vault")
+
+		assert.EqualError(t, graph.Validate(), `task graph task "secrets" depends on unknown task "vault"`)
+
+		graph.Add("vault", fn)
+		require.NoError(t, graph.Validate())
+
+		graph.Add("vault", fn)
+		assert.EqualError(t, graph.Validate(), `task graph has duplicate task "vault"`)
+	})
+}
@@ -16,30 +16,224 @@ package task
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 	"unsafe"
 )
 
+// TaskState is the state of a task in a group.
+type TaskState int
+
+const (
+	// TaskPending is the state of a task waiting for the group limit to start.
+	TaskPending TaskState = iota
+	// TaskRunning is the state of a running task.
+	TaskRunning
+	// TaskSucceeded is the state of a task that returned no error.
+	TaskSucceeded
+	// TaskFailed is the state of a task that returned an error.
+	TaskFailed
+	// TaskCanceled is the state of a task that returned no error or a context error after the group
+	// was canceled, or that was never started because the group was canceled.
+	TaskCanceled
+)
+
+// String returns the state name.
+func (s TaskState) String() string {
+	switch s {
+	case TaskPending:
+		return "pending"
+	case TaskRunning:
+		return "running"
+	case TaskSucceeded:
+		return "succeeded"
+	case TaskFailed:
+		return "failed"
+	case TaskCanceled:
+		return "canceled"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(s))
+	}
+}
+
+// TaskStatus is the status of a task in a group, as returned by Group.Snapshot when the tasks are
+// tracked (see Group.SetTrackTasks).
+type TaskStatus struct {
+	// Index is the order in which the task was added to the group, starting from 0.
+	Index int
+	// Name is the task name, empty for the tasks not started with GoNamed.
+	Name  string
+	State TaskState
+	// StartTime is zero for the pending tasks.
+	StartTime time.Time
+	// EndTime is zero for the pending and running tasks.
+	EndTime time.Time
+	// Err is the error returned by the task.
+	Err error
+}
+
+// Duration returns how long the task ran, or has been running so far.
+func (s TaskStatus) Duration() time.Duration {
+	switch {
+	case s.StartTime.IsZero():
+		return 0
+	case s.EndTime.IsZero():
+		return time.Since(s.StartTime)
+	default:
+		return s.EndTime.Sub(s.StartTime)
+	}
+}
+
 // Group is used to wait for a group of tasks to finish.
 //
-// It will stop all the tasks on the first task failure, and the Wait() method will return only the
-// first encountered error.
+// By default, it will stop all the tasks on the first task failure, and the Wait() method will
+// return only the first encountered error. See SetCollectErrors and SetCancelOnError for changing
+// this behavior.
 type Group struct {
 	wg             sync.WaitGroup
 	ctx            context.Context //nolint:containedctx
 	cancelFunc     context.CancelFunc
 	firstRunErrPtr unsafe.Pointer
+	// sem limits the number of running tasks, it is nil when there is no limit.
+	sem           chan struct{}
+	nextIndex     int64
+	cancelOnError bool
+	collectErrors bool
+	trackTasks    bool
+
+	// mu protects the taskErrs and tasks properties
+	mu       sync.Mutex
+	taskErrs []*TaskError
+	tasks    []*TaskStatus
 }
 
 // NewGroup creates new task group instance.
 func NewGroup() *Group {
-	ctx, cancel := context.WithCancel(context.Background())
+	group, _ := NewGroupWithContext(context.Background())
+
+	return group
+}
+
+// NewGroupWithContext creates new task group instance with a context derived from parent, so
+// the tasks receive the parent values and are canceled together with the parent.
+//
+// The returned context is the one passed to the tasks, it is canceled when the group is canceled.
+// Like with Group.Cancel, a parent cancellation does not make Wait return an error.
+func NewGroupWithContext(parent context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancel(parent)
 
 	return &Group{
-		ctx:        ctx,
-		cancelFunc: cancel,
+		ctx:           ctx,
+		cancelFunc:    cancel,
+		cancelOnError: true,
+	}, ctx
+}
+
+// TaskError is an error returned by a task of a group in collect errors mode.
+type TaskError struct {
+	// Index is the order in which the task was added to the group, starting from 0.
+	Index int
+	// Name is the task name, empty for the tasks not started with GoNamed.
+	Name string
+	// Err is the error returned by the task.
+	Err error
+}
+
+// Error returns the error message.
+func (err *TaskError) Error() string {
+	if err.Name != "" {
+		return fmt.Sprintf("task %d %q: %v", err.Index, err.Name, err.Err)
+	}
+
+	return fmt.Sprintf("task %d: %v", err.Index, err.Err)
+}
+
+// Unwrap returns the error returned by the task.
+func (err *TaskError) Unwrap() error {
+	return err.Err
+}
+
+// GroupError is returned by Group.Wait in collect errors mode, it contains the errors of all
+// the failed tasks.
+//
+// It works with errors.Is and errors.As, which check all the task errors.
+type GroupError struct {
+	// Errors are the task errors ordered by the task index.
+	Errors []*TaskError
+}
+
+// Error returns the error message.
+func (err *GroupError) Error() string {
+	messages := make([]string, 0, len(err.Errors))
+	for _, taskErr := range err.Errors {
+		messages = append(messages, taskErr.Error())
+	}
+
+	return fmt.Sprintf("%d tasks failed: %s", len(err.Errors), strings.Join(messages, "; "))
+}
+
+// Unwrap returns the task errors.
+func (err *GroupError) Unwrap() []error {
+	errs := make([]error, 0, len(err.Errors))
+	for _, taskErr := range err.Errors {
+		errs = append(errs, taskErr)
+	}
+
+	return errs
+}
+
+// SetCollectErrors enables or disables the collect errors mode.
+//
+// In collect errors mode, the errors of all the failed tasks are collected and returned by Wait as
+// a GroupError.
+//
+// SetCollectErrors must be called before any task is started.
+func (g *Group) SetCollectErrors(collect bool) {
+	g.collectErrors = collect
+}
+
+// SetCancelOnError controls whether a task failure cancels all the other tasks in the group.
+// It is enabled by default.
+//
+// When disabled, the other tasks continue to run, which is mostly useful in collect errors mode.
+//
+// SetCancelOnError must be called before any task is started.
+func (g *Group) SetCancelOnError(cancel bool) {
+	g.cancelOnError = cancel
+}
+
+// SetTrackTasks enables or disables the tracking of the task statuses returned by Snapshot.
+// It is disabled by default, since the status of every task is kept until the group is dropped.
+//
+// SetTrackTasks must be called before any task is started.
+func (g *Group) SetTrackTasks(track bool) {
+	g.trackTasks = track
+}
+
+// SetLimit limits the number of tasks running at the same time to n.
+// A negative n means no limit, which is the default.
+//
+// When the limit is reached, Go blocks until a running task finishes, and TryGo does not start
+// the task.
+//
+// SetLimit must not be called while there are running tasks in the group.
+func (g *Group) SetLimit(n int) {
+	if g.sem != nil && len(g.sem) != 0 {
+		panic(fmt.Errorf("task: modify limit while %d tasks are running", len(g.sem)))
 	}
+
+	if n < 0 {
+		g.sem = nil
+
+		return
+	}
+
+	g.sem = make(chan struct{}, n)
 }
 
 // Go runs tasks in the group.
@@ -47,31 +241,181 @@ func NewGroup() *Group {
 // Every task is run in new goroutine.
 // When a task returns an error, all the tasks in the group are canceled.
 //
+// If the group has a limit (see SetLimit), Go blocks until every task can be started. Once the group
+// is canceled, the tasks which are not yet started are skipped.
+//
 // Typically one should schedule tasks with the Group.Go() method and then wait for all of them to
 // finish by using the Group.Wait() method.
 func (g *Group) Go(tasks ...TaskFunc) {
+	for _, fn := range tasks {
+		if !g.goNamed("", fn) {
+			return
+		}
+	}
+}
+
+// GoNamed works like Go, but runs a single task with a name identifying it in Snapshot and
+// TaskError.
+func (g *Group) GoNamed(name string, fn TaskFunc) {
+	g.goNamed(name, fn)
+}
+
+// goNamed starts the task once the group limit allows it, and reports whether it was started.
+func (g *Group) goNamed(name string, fn TaskFunc) bool {
 	if g.ctx.Err() != nil {
-		return
+		return false
 	}
 
-	for _, fn := range tasks {
-		g.wg.Add(1)
-		go func(fn TaskFunc) {
-			defer g.wg.Done()
+	task := g.newTask(name)
 
-			err := fn(g.ctx)
-			if err != nil {
-				g.cancelWithError(err)
+	if g.sem != nil {
+		select {
+		case g.sem <- struct{}{}:
+		case <-g.ctx.Done():
+			if task.status != nil {
+				g.mu.Lock()
+				task.status.State = TaskCanceled
+				g.mu.Unlock()
 			}
-		}(fn)
+
+			return false
+		}
+	}
+
+	g.start(task, fn)
+
+	return true
+}
+
+// TryGo runs the task in the group only if it can be started without blocking, i.e. the group
+// limit is not reached (see SetLimit).
+//
+// It reports whether the task was started. Tasks are never started once the group is canceled.
+func (g *Group) TryGo(fn TaskFunc) bool {
+	if g.ctx.Err() != nil {
+		return false
+	}
+
+	if g.sem != nil {
+		select {
+		case g.sem <- struct{}{}:
+		default:
+			return false
+		}
+	}
+
+	g.start(g.newTask(""), fn)
+
+	return true
+}
+
+// groupTask identifies a task in the group.
+type groupTask struct {
+	index int
+	name  string
+	// status is nil when the tasks are not tracked
+	status *TaskStatus
+}
+
+// newTask assigns the next index to the task, and adds its pending status to the group when
+// the tasks are tracked.
+func (g *Group) newTask(name string) groupTask {
+	task := groupTask{
+		index: int(atomic.AddInt64(&g.nextIndex, 1) - 1),
+		name:  name,
 	}
+
+	if g.trackTasks {
+		task.status = &TaskStatus{
+			Index: task.index,
+			Name:  name,
+			State: TaskPending,
+		}
+
+		g.mu.Lock()
+		g.tasks = append(g.tasks, task.status)
+		g.mu.Unlock()
+	}
+
+	return task
+}
+
+// start runs the task in a new goroutine, the group semaphore must be already acquired.
+func (g *Group) start(task groupTask, fn TaskFunc) {
+	sem := g.sem
+
+	if task.status != nil {
+		g.mu.Lock()
+		task.status.State = TaskRunning
+		task.status.StartTime = time.Now()
+		g.mu.Unlock()
+	}
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+
+		if sem != nil {
+			defer func() {
+				<-sem
+			}()
+		}
+
+		err := fn(g.ctx)
+		if task.status != nil {
+			g.finish(task.status, err)
+		}
+
+		if err != nil {
+			g.taskFailed(task, err)
+		}
+	}()
+}
+
+func (g *Group) finish(task *TaskStatus, err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	task.EndTime = time.Now()
+	task.Err = err
+
+	switch {
+	case g.ctx.Err() != nil &&
+		(err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)):
+		task.State = TaskCanceled
+	case err != nil:
+		task.State = TaskFailed
+	default:
+		task.State = TaskSucceeded
+	}
+}
+
+// Snapshot returns the current status of all the tasks added to the group, ordered by index.
+// It returns no statuses, unless the tracking is enabled with SetTrackTasks.
+//
+// It is safe to call concurrently with the running tasks, e.g. from a debug endpoint.
+func (g *Group) Snapshot() []TaskStatus {
+	g.mu.Lock()
+	snapshot := make([]TaskStatus, 0, len(g.tasks))
+	for _, task := range g.tasks {
+		snapshot = append(snapshot, *task)
+	}
+	g.mu.Unlock()
+
+	sort.Slice(snapshot, func(i, j int) bool {
+		return snapshot[i].Index < snapshot[j].Index
+	})
+
+	return snapshot
 }
 
 // Wait until all tasks are stopped.
-// Returns the first encountered error if any.
-// If the context is done all tasks are canceled and the context error is returned.
+// Returns the first encountered error if any, or GroupError with all task errors in collect
+// errors mode.
+// If the context is done all tasks are canceled and the context error is returned. A context that is
+// never done, like context.Background() or context.TODO(), only waits for the tasks.
 func (g *Group) Wait(ctx context.Context) error {
-	if ctx != context.TODO() {
+	if ctx.Done() != nil {
 		doneCh := make(chan struct{})
 		defer close(doneCh)
 
@@ -87,6 +431,21 @@ func (g *Group) Wait(ctx context.Context) error {
 
 	g.wg.Wait()
 
+	if g.collectErrors {
+		g.mu.Lock()
+		taskErrs := make([]*TaskError, len(g.taskErrs))
+		copy(taskErrs, g.taskErrs)
+		g.mu.Unlock()
+
+		if len(taskErrs) > 0 {
+			sort.Slice(taskErrs, func(i, j int) bool {
+				return taskErrs[i].Index < taskErrs[j].Index
+			})
+
+			return &GroupError{Errors: taskErrs}
+		}
+	}
+
 	err := (*error)(atomic.LoadPointer(&g.firstRunErrPtr))
 	if err != nil {
 		return *err
@@ -95,12 +454,31 @@ func (g *Group) Wait(ctx context.Context) error {
 	return nil
 }
 
-func (g *Group) cancelWithError(err error) {
-	swapped := atomic.CompareAndSwapPointer(&g.firstRunErrPtr, nil, (unsafe.Pointer)(&err))
+func (g *Group) taskFailed(task groupTask, err error) {
+	if g.collectErrors {
+		g.mu.Lock()
+		g.taskErrs = append(g.taskErrs, &TaskError{Index: task.index, Name: task.name, Err: err})
+		g.mu.Unlock()
+	}
+
+	if g.cancelOnError {
+		g.cancelWithError(err)
 
-	if swapped {
-		g.cancelFunc()
+		return
 	}
+
+	g.storeFirstError(err)
+}
+
+// cancelWithError stores the error if it is the first one, and cancels the group regardless, since
+// the first error may be stored without canceling in SetCancelOnError(false) mode.
+func (g *Group) cancelWithError(err error) {
+	g.storeFirstError(err)
+	g.cancelFunc()
+}
+
+func (g *Group) storeFirstError(err error) {
+	atomic.CompareAndSwapPointer(&g.firstRunErrPtr, nil, (unsafe.Pointer)(&err))
 }
 
 // Cancel cancels all the tasks.
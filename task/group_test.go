@@ -16,9 +16,12 @@ package task_test
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/sumup-oss/go-pkgs/task"
 )
@@ -143,6 +146,361 @@ func TestGroup_Go(t *testing.T) {
 	})
 }
 
+func TestNewGroupWithContext(t *testing.T) {
+	type ctxKey struct{}
+
+	t.Run("it passes the parent values to the tasks", func(t *testing.T) {
+		t.Parallel()
+
+		parent := context.WithValue(context.Background(), ctxKey{}, "trace-id")
+		group, _ := task.NewGroupWithContext(parent)
+
+		var value any
+
+		group.Go(func(ctx context.Context) error {
+			value = ctx.Value(ctxKey{})
+
+			return nil
+		})
+
+		err := group.Wait(context.TODO())
+		assert.NoError(t, err)
+		assert.Equal(t, "trace-id", value)
+	})
+
+	t.Run("when the parent is canceled, it cancels all the tasks", func(t *testing.T) {
+		t.Parallel()
+
+		parent, cancel := context.WithCancel(context.Background())
+		group, ctx := task.NewGroupWithContext(parent)
+
+		foo := NewTestTask(nil)
+		group.Go(foo.Run)
+		<-foo.RunReady
+
+		cancel()
+
+		err := group.Wait(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, foo.StopCount)
+		assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	})
+
+	t.Run("when a task returns an error, it cancels the returned context", func(t *testing.T) {
+		t.Parallel()
+
+		group, ctx := task.NewGroupWithContext(context.Background())
+
+		group.Go(func(ctx context.Context) error {
+			return assert.AnError
+		})
+
+		err := group.Wait(context.Background())
+		assert.Equal(t, assert.AnError, err)
+		assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	})
+}
+
+func TestGroup_SetLimit(t *testing.T) {
+	t.Run("it runs at most limit tasks at the same time", func(t *testing.T) {
+		t.Parallel()
+
+		group := task.NewGroup()
+		group.SetLimit(1)
+
+		foo := NewTestTask(nil)
+		bar := NewTestTask(nil)
+
+		started := make(chan struct{})
+		go func() {
+			group.Go(foo.Run, bar.Run)
+			close(started)
+		}()
+
+		<-foo.RunReady
+
+		select {
+		case <-bar.RunReady:
+			t.Fatal("bar started before foo finished")
+		default:
+		}
+
+		foo.RunUntil <- nil
+		<-bar.RunReady
+		<-started
+		bar.RunUntil <- nil
+
+		err := group.Wait(context.Background())
+		assert.NoError(t, err)
+
+		assert.Equal(t, 1, foo.RunCount)
+		assert.Equal(t, 1, bar.RunCount)
+	})
+
+	t.Run("when the group is canceled while Go is blocked, it skips the remaining tasks", func(t *testing.T) {
+		t.Parallel()
+
+		group := task.NewGroup()
+		group.SetLimit(1)
+
+		foo := NewTestTask(nil)
+		bar := NewTestTask(nil)
+
+		done := make(chan struct{})
+		go func() {
+			group.Go(foo.Run, bar.Run)
+			close(done)
+		}()
+
+		<-foo.RunReady
+		group.Cancel()
+		<-done
+
+		err := group.Wait(context.Background())
+		assert.NoError(t, err)
+
+		assert.Equal(t, 1, foo.RunCount)
+		assert.Equal(t, 0, bar.RunCount)
+	})
+}
+
+func TestGroup_TryGo(t *testing.T) {
+	t.Run("when the limit is reached, it does not start the task", func(t *testing.T) {
+		t.Parallel()
+
+		group := task.NewGroup()
+		group.SetLimit(1)
+
+		foo := NewTestTask(nil)
+		bar := NewTestTask(nil)
+
+		assert.True(t, group.TryGo(foo.Run))
+		<-foo.RunReady
+		assert.False(t, group.TryGo(bar.Run))
+
+		foo.RunUntil <- nil
+
+		err := group.Wait(context.Background())
+		assert.NoError(t, err)
+
+		assert.True(t, group.TryGo(bar.Run))
+		bar.RunUntil <- nil
+
+		err = group.Wait(context.Background())
+		assert.NoError(t, err)
+	})
+
+	t.Run("when the group is canceled, it does not start the task", func(t *testing.T) {
+		t.Parallel()
+
+		group := task.NewGroup()
+		group.Cancel()
+
+		assert.False(t, group.TryGo(NewTestTask(nil).Run))
+	})
+}
+
+func TestGroup_SetCollectErrors(t *testing.T) {
+	t.Run("it returns the errors of all the failed tasks", func(t *testing.T) {
+		t.Parallel()
+
+		group := task.NewGroup()
+		group.SetCollectErrors(true)
+		group.SetCancelOnError(false)
+
+		fooErr := errors.New("fooErr")
+		foo := NewTestTask(fooErr)
+		bar := NewTestTask(nil)
+		baz := NewTestTask(assert.AnError)
+
+		group.Go(foo.Run, bar.Run, baz.Run)
+
+		<-foo.RunReady
+		<-bar.RunReady
+		<-baz.RunReady
+
+		baz.RunUntil <- assert.AnError
+		foo.RunUntil <- fooErr
+		bar.RunUntil <- nil
+
+		err := group.Wait(context.Background())
+
+		var groupErr *task.GroupError
+		require.ErrorAs(t, err, &groupErr)
+		require.Len(t, groupErr.Errors, 2)
+		assert.Equal(t, 0, groupErr.Errors[0].Index)
+		assert.Equal(t, fooErr, groupErr.Errors[0].Err)
+		assert.Equal(t, 2, groupErr.Errors[1].Index)
+		assert.Equal(t, assert.AnError, groupErr.Errors[1].Err)
+		assert.ErrorIs(t, err, fooErr)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.EqualError(t, err, "2 tasks failed: task 0: fooErr; task 2: "+assert.AnError.Error())
+
+		assert.Equal(t, 0, bar.StopCount)
+	})
+
+	t.Run("when a task failed without canceling and the wait deadline is exceeded, it cancels all the tasks", func(t *testing.T) {
+		t.Parallel()
+
+		group := task.NewGroup()
+		group.SetCancelOnError(false)
+
+		foo := NewTestTask(assert.AnError)
+		bar := NewTestTask(nil)
+
+		group.Go(foo.Run, bar.Run)
+
+		<-foo.RunReady
+		<-bar.RunReady
+
+		foo.RunUntil <- assert.AnError
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err := group.Wait(ctx)
+		assert.Equal(t, assert.AnError, err)
+		assert.Equal(t, 1, bar.StopCount)
+	})
+
+	t.Run("when no task fails, it returns no error", func(t *testing.T) {
+		t.Parallel()
+
+		group := task.NewGroup()
+		group.SetCollectErrors(true)
+
+		foo := NewTestTask(nil)
+		group.Go(foo.Run)
+		foo.RunUntil <- nil
+
+		err := group.Wait(context.Background())
+		assert.NoError(t, err)
+	})
+}
+
+func TestGroup_Snapshot(t *testing.T) {
+	t.Run("it reports the state, times and error of the named tasks", func(t *testing.T) {
+		t.Parallel()
+
+		group := task.NewGroup()
+		group.SetTrackTasks(true)
+		group.SetLimit(1)
+
+		vault := NewTestTask(nil)
+		helm := NewTestTask(nil)
+
+		group.GoNamed("vault", vault.Run)
+		<-vault.RunReady
+
+		done := make(chan struct{})
+		go func() {
+			group.GoNamed("helm", helm.Run)
+			close(done)
+		}()
+
+		require.Eventually(t, func() bool {
+			return len(group.Snapshot()) == 2
+		}, time.Second, time.Millisecond)
+
+		snapshot := group.Snapshot()
+		assert.Equal(t, "vault", snapshot[0].Name)
+		assert.Equal(t, task.TaskRunning, snapshot[0].State)
+		assert.False(t, snapshot[0].StartTime.IsZero())
+		assert.True(t, snapshot[0].EndTime.IsZero())
+		assert.Equal(t, "helm", snapshot[1].Name)
+		assert.Equal(t, task.TaskPending, snapshot[1].State)
+		assert.True(t, snapshot[1].StartTime.IsZero())
+		assert.Zero(t, snapshot[1].Duration())
+
+		vault.RunUntil <- nil
+		<-done
+		<-helm.RunReady
+		helm.RunUntil <- assert.AnError
+
+		err := group.Wait(context.Background())
+		assert.Equal(t, assert.AnError, err)
+
+		snapshot = group.Snapshot()
+		require.Len(t, snapshot, 2)
+		assert.Equal(t, task.TaskSucceeded, snapshot[0].State)
+		assert.NoError(t, snapshot[0].Err)
+		assert.False(t, snapshot[0].EndTime.Before(snapshot[0].StartTime))
+		assert.Equal(t, 1, snapshot[1].Index)
+		assert.Equal(t, task.TaskFailed, snapshot[1].State)
+		assert.Equal(t, assert.AnError, snapshot[1].Err)
+		assert.Equal(t, snapshot[1].EndTime.Sub(snapshot[1].StartTime), snapshot[1].Duration())
+	})
+
+	t.Run("when the group is canceled, it reports the stopped and skipped tasks as canceled", func(t *testing.T) {
+		t.Parallel()
+
+		group := task.NewGroup()
+		group.SetTrackTasks(true)
+		group.SetLimit(1)
+
+		foo := NewTestTask(nil)
+		bar := NewTestTask(nil)
+
+		done := make(chan struct{})
+		go func() {
+			group.Go(foo.Run, bar.Run)
+			close(done)
+		}()
+
+		<-foo.RunReady
+		require.Eventually(t, func() bool {
+			return len(group.Snapshot()) == 2
+		}, time.Second, time.Millisecond)
+
+		group.Cancel()
+		<-done
+
+		err := group.Wait(context.Background())
+		assert.NoError(t, err)
+
+		snapshot := group.Snapshot()
+		require.Len(t, snapshot, 2)
+		assert.Equal(t, task.TaskCanceled, snapshot[0].State)
+		assert.False(t, snapshot[0].EndTime.IsZero())
+		assert.Equal(t, task.TaskCanceled, snapshot[1].State)
+		assert.True(t, snapshot[1].StartTime.IsZero())
+		assert.Equal(t, 0, bar.RunCount)
+	})
+
+	t.Run("when the tasks are not tracked, it returns no statuses", func(t *testing.T) {
+		t.Parallel()
+
+		group := task.NewGroup()
+
+		group.GoNamed("vault", func(ctx context.Context) error {
+			return nil
+		})
+
+		err := group.Wait(context.Background())
+		assert.NoError(t, err)
+		assert.Empty(t, group.Snapshot())
+	})
+
+	t.Run("in collect errors mode, it names the failed tasks in the errors", func(t *testing.T) {
+		t.Parallel()
+
+		group := task.NewGroup()
+		group.SetCollectErrors(true)
+
+		group.GoNamed("helm", func(ctx context.Context) error {
+			return assert.AnError
+		})
+
+		err := group.Wait(context.Background())
+
+		var groupErr *task.GroupError
+		require.ErrorAs(t, err, &groupErr)
+		require.Len(t, groupErr.Errors, 1)
+		assert.Equal(t, "helm", groupErr.Errors[0].Name)
+		assert.EqualError(t, err, `1 tasks failed: task 0 "helm": `+assert.AnError.Error())
+	})
+}
+
 func TestGroup_Cancel(t *testing.T) {
 	t.Run("it cancels all the tasks", func(t *testing.T) {
 		t.Parallel()
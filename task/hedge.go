@@ -0,0 +1,154 @@
+// Copyright 2026 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"context"
+	"time"
+
+	"github.com/sumup-oss/go-pkgs/backoff"
+)
+
+// HedgeMetric observes the hedged calls of a task decorated with HedgeWithMetric.
+type HedgeMetric interface {
+	// ObserveHedgeStarted is called for every duplicate call started.
+	ObserveHedgeStarted()
+	// ObserveHedgeWon is called when a duplicate call succeeds before the original call.
+	ObserveHedgeWon()
+}
+
+// HedgeConfig is the configuration of HedgeWithConfig.
+type HedgeConfig struct {
+	// Delay is how long to wait for a call before starting a duplicate one.
+	Delay time.Duration
+	// MaxHedges is the maximum number of duplicate calls. Negative values are treated as zero.
+	MaxHedges int
+	// Metric observes the hedged calls. It is optional.
+	Metric HedgeMetric
+	// Clock is used for the delays, which allows faking the time in tests.
+	// The default is backoff.SystemClock.
+	Clock backoff.Clock
+}
+
+// Hedge returns a TaskFuncDecorator for hedging idempotent tasks with long tail latency.
+//
+// When the task does not return after the delay, a duplicate call is started, up to maxHedges
+// duplicates, each after another delay. The first successful call wins, and the other calls are
+// canceled without waiting for them to return.
+//
+// Failed calls are not retried. When all the started calls fail, the error of the last one is
+// returned.
+func Hedge(delay time.Duration, maxHedges int) TaskFuncDecorator {
+	return HedgeWithConfig(&HedgeConfig{Delay: delay, MaxHedges: maxHedges})
+}
+
+// HedgeWithMetric works like Hedge, but reports the hedged calls to the metric.
+func HedgeWithMetric(delay time.Duration, maxHedges int, metric HedgeMetric) TaskFuncDecorator {
+	return HedgeWithConfig(&HedgeConfig{Delay: delay, MaxHedges: maxHedges, Metric: metric})
+}
+
+// HedgeWithConfig works like Hedge, but it is configured with HedgeConfig.
+func HedgeWithConfig(config *HedgeConfig) TaskFuncDecorator {
+	if config.MaxHedges < 0 {
+		config.MaxHedges = 0
+	}
+
+	if config.Clock == nil {
+		config.Clock = backoff.SystemClock{}
+	}
+
+	return func(fn TaskFunc) TaskFunc {
+		return func(ctx context.Context) error {
+			return hedge(ctx, config, fn)
+		}
+	}
+}
+
+type hedgeResult struct {
+	hedged bool
+	err    error
+}
+
+func hedge(ctx context.Context, config *HedgeConfig, fn TaskFunc) error {
+	hedgeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// buffered, so the canceled calls can return after hedge has returned
+	resultCh := make(chan hedgeResult, config.MaxHedges+1)
+	start := func(hedged bool) {
+		go func() {
+			resultCh <- hedgeResult{hedged: hedged, err: fn(hedgeCtx)}
+		}()
+	}
+
+	start(false)
+
+	started, running := 1, 1
+
+	// timer is nil when no more duplicate calls can be started
+	var timer backoff.Timer
+	if config.MaxHedges > 0 {
+		timer = config.Clock.NewTimer(config.Delay)
+	}
+
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timerC(timer):
+			start(true)
+			started++
+			running++
+
+			if config.Metric != nil {
+				config.Metric.ObserveHedgeStarted()
+			}
+
+			timer = nil
+			if started <= config.MaxHedges {
+				timer = config.Clock.NewTimer(config.Delay)
+			}
+		case result := <-resultCh:
+			running--
+
+			if result.err == nil {
+				if result.hedged && config.Metric != nil {
+					config.Metric.ObserveHedgeWon()
+				}
+
+				return nil
+			}
+
+			if running == 0 {
+				return result.err
+			}
+		}
+	}
+}
+
+// timerC returns the timer channel, or nil channel blocking forever when there is no timer.
+func timerC(timer backoff.Timer) <-chan time.Time {
+	if timer == nil {
+		return nil
+	}
+
+	return timer.C()
+}
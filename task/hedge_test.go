@@ -0,0 +1,170 @@
+// Copyright 2026 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sumup-oss/go-pkgs/backoff/backofftest"
+	"github.com/sumup-oss/go-pkgs/task"
+)
+
+type testHedgeMetric struct {
+	started int32
+	won     int32
+}
+
+func (m *testHedgeMetric) ObserveHedgeStarted() {
+	atomic.AddInt32(&m.started, 1)
+}
+
+func (m *testHedgeMetric) ObserveHedgeWon() {
+	atomic.AddInt32(&m.won, 1)
+}
+
+func TestHedge(t *testing.T) {
+	t.Run("when the task returns before the delay, it does not hedge", func(t *testing.T) {
+		t.Parallel()
+
+		metric := &testHedgeMetric{}
+
+		var calls int32
+		fn := task.NewTaskFunc(
+			func(ctx context.Context) error {
+				atomic.AddInt32(&calls, 1)
+
+				return nil
+			},
+			task.HedgeWithMetric(time.Hour, 2, metric),
+		)
+
+		assert.NoError(t, fn(context.Background()))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+		assert.Equal(t, int32(0), atomic.LoadInt32(&metric.started))
+	})
+
+	t.Run("when the hedged call succeeds first, it returns and cancels the original call", func(t *testing.T) {
+		t.Parallel()
+
+		clock := backofftest.NewFakeClock(time.Now())
+		metric := &testHedgeMetric{}
+		originalStarted := make(chan struct{})
+		originalCanceled := make(chan struct{})
+
+		var calls int32
+		fn := task.NewTaskFunc(
+			func(ctx context.Context) error {
+				if atomic.AddInt32(&calls, 1) == 1 {
+					close(originalStarted)
+					<-ctx.Done()
+					close(originalCanceled)
+
+					return ctx.Err()
+				}
+
+				return nil
+			},
+			task.HedgeWithConfig(&task.HedgeConfig{Delay: time.Second, MaxHedges: 2, Metric: metric, Clock: clock}),
+		)
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- fn(context.Background())
+		}()
+
+		<-originalStarted
+		clock.WaitForTimers(1)
+		clock.Advance(time.Second)
+
+		assert.NoError(t, <-errCh)
+		<-originalCanceled
+		assert.Equal(t, int32(1), atomic.LoadInt32(&metric.started))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&metric.won))
+	})
+
+	t.Run("when all the calls fail, it returns the last error after at most maxHedges hedges", func(t *testing.T) {
+		t.Parallel()
+
+		clock := backofftest.NewFakeClock(time.Now())
+		release := make(chan struct{})
+
+		var calls int32
+		fn := task.NewTaskFunc(
+			func(ctx context.Context) error {
+				if atomic.AddInt32(&calls, 1) == 3 {
+					close(release)
+				}
+
+				<-release
+
+				return assert.AnError
+			},
+			task.HedgeWithConfig(&task.HedgeConfig{Delay: time.Second, MaxHedges: 2, Clock: clock}),
+		)
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- fn(context.Background())
+		}()
+
+		clock.WaitForTimers(1)
+		clock.Advance(time.Second)
+		clock.WaitForTimers(2)
+		clock.Advance(time.Second)
+
+		assert.Equal(t, assert.AnError, <-errCh)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+		assert.Equal(t, []time.Duration{time.Second, time.Second}, clock.Sleeps())
+	})
+
+	t.Run("when maxHedges is negative, it only runs the task once", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int32
+		fn := task.NewTaskFunc(
+			func(ctx context.Context) error {
+				atomic.AddInt32(&calls, 1)
+
+				return assert.AnError
+			},
+			task.Hedge(time.Nanosecond, -2),
+		)
+
+		assert.Equal(t, assert.AnError, fn(context.Background()))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("when the context is done, it returns the context error", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		fn := task.NewTaskFunc(
+			func(ctx context.Context) error {
+				cancel()
+				<-ctx.Done()
+
+				return ctx.Err()
+			},
+			task.Hedge(time.Hour, 1),
+		)
+
+		assert.Equal(t, context.Canceled, fn(ctx))
+	})
+}
@@ -0,0 +1,327 @@
+// Copyright 2026 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// ErrPoolClosed is returned by Pool.Submit when the pool is closed or stopped.
+var ErrPoolClosed = errors.New("pool closed")
+
+// PoolConfig is the configuration of Pool.
+type PoolConfig struct {
+	// Workers is the number of jobs processed at the same time. The default is GOMAXPROCS.
+	Workers int
+	// QueueSize is how many submitted jobs can wait for a worker, before Submit blocks.
+	// The default is Workers.
+	QueueSize int
+	// JobTimeout limits how long a single job can run. Zero means no timeout.
+	JobTimeout time.Duration
+	// Ordered makes the results come in the order the jobs were submitted.
+	// By default, the results come as soon as the jobs are done.
+	Ordered bool
+}
+
+// PoolFunc processes a single Pool job.
+type PoolFunc[In, Out any] func(ctx context.Context, input In) (Out, error)
+
+// PoolResult is the result of a Pool job.
+type PoolResult[In, Out any] struct {
+	// Index is the order in which the job was submitted, starting from 0.
+	Index  int
+	Input  In
+	Output Out
+	Err    error
+}
+
+type poolJob[In, Out any] struct {
+	index  int
+	input  In
+	result chan *PoolResult[In, Out]
+}
+
+// Pool processes the submitted jobs with a fixed number of workers.
+//
+// The jobs are processed while Run is running. Run returns once the pool is closed and all
+// the jobs are processed, or when its context is done.
+// The results must be received from Results until the channel is closed.
+//
+// Example:
+//
+//	pool := task.NewPool(&task.PoolConfig{Workers: 4}, fetch)
+//
+//	group := task.NewGroup()
+//	group.Go(pool.Run)
+//	group.Go(func(ctx context.Context) error {
+//		defer pool.Close()
+//
+//		for _, url := range urls {
+//			err := pool.Submit(ctx, url)
+//			if err != nil {
+//				return err
+//			}
+//		}
+//
+//		return nil
+//	})
+//
+//	for result := range pool.Results() {
+//		...
+//	}
+type Pool[In, Out any] struct {
+	config  *PoolConfig
+	fn      PoolFunc[In, Out]
+	jobs    chan *poolJob[In, Out]
+	pending chan *poolJob[In, Out]
+	results chan *PoolResult[In, Out]
+	stopped chan struct{}
+
+	mu         sync.Mutex
+	closed     bool
+	submitting sync.WaitGroup
+
+	submitMu  sync.Mutex
+	nextIndex int
+}
+
+// NewPool creates Pool instance processing the jobs with fn.
+func NewPool[In, Out any](config *PoolConfig, fn PoolFunc[In, Out]) *Pool[In, Out] {
+	if config.Workers <= 0 {
+		config.Workers = runtime.GOMAXPROCS(0)
+	}
+
+	if config.QueueSize <= 0 {
+		config.QueueSize = config.Workers
+	}
+
+	pool := &Pool[In, Out]{
+		config:  config,
+		fn:      fn,
+		jobs:    make(chan *poolJob[In, Out], config.QueueSize),
+		results: make(chan *PoolResult[In, Out]),
+		stopped: make(chan struct{}),
+	}
+
+	if config.Ordered {
+		pool.pending = make(chan *poolJob[In, Out], config.QueueSize+config.Workers)
+	}
+
+	return pool
+}
+
+// Submit adds a job to the queue.
+//
+// It blocks while the queue is full. It returns the context error if the context is done first,
+// and ErrPoolClosed if the pool is closed or Run has returned.
+func (p *Pool[In, Out]) Submit(ctx context.Context, input In) error {
+	select {
+	case <-p.stopped:
+		return ErrPoolClosed
+	default:
+	}
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+
+		return ErrPoolClosed
+	}
+
+	p.submitting.Add(1)
+	p.mu.Unlock()
+
+	defer p.submitting.Done()
+
+	// keeps the order of the jobs in both the queues
+	p.submitMu.Lock()
+	defer p.submitMu.Unlock()
+
+	job := &poolJob[In, Out]{
+		index: p.nextIndex,
+		input: input,
+	}
+
+	if p.config.Ordered {
+		job.result = make(chan *PoolResult[In, Out], 1)
+
+		select {
+		case p.pending <- job:
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-p.stopped:
+			return ErrPoolClosed
+		}
+	}
+
+	select {
+	case p.jobs <- job:
+	case <-ctx.Done():
+		p.drop(job)
+
+		return ctx.Err()
+	case <-p.stopped:
+		p.drop(job)
+
+		return ErrPoolClosed
+	}
+
+	p.nextIndex++
+
+	return nil
+}
+
+// drop marks the job already added to the ordered results as not submitted.
+func (p *Pool[In, Out]) drop(job *poolJob[In, Out]) {
+	if job.result != nil {
+		job.result <- nil
+	}
+}
+
+// Close stops accepting new jobs. Run returns once all the submitted jobs are processed.
+//
+// Close waits for the Submit calls in progress to return.
+func (p *Pool[In, Out]) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+
+		return
+	}
+
+	p.closed = true
+	p.mu.Unlock()
+
+	p.submitting.Wait()
+	close(p.jobs)
+
+	if p.pending != nil {
+		close(p.pending)
+	}
+}
+
+// Results returns the channel of the job results. It is closed when Run returns.
+func (p *Pool[In, Out]) Results() <-chan *PoolResult[In, Out] {
+	return p.results
+}
+
+// Run processes the jobs until the pool is closed and all the jobs are processed, or until
+// the context is done. When the context is done, the jobs left in the queue are dropped.
+//
+// Run must be called only once.
+func (p *Pool[In, Out]) Run(ctx context.Context) error {
+	defer close(p.results)
+	defer close(p.stopped)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < p.config.Workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			p.work(ctx)
+		}()
+	}
+
+	if p.config.Ordered {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			p.emitOrdered(ctx)
+		}()
+	}
+
+	wg.Wait()
+
+	return nil
+}
+
+func (p *Pool[In, Out]) work(ctx context.Context) {
+	for {
+		var job *poolJob[In, Out]
+
+		select {
+		case <-ctx.Done():
+			return
+		case job = <-p.jobs:
+			if job == nil {
+				return
+			}
+		}
+
+		result := p.process(ctx, job)
+
+		if job.result != nil {
+			job.result <- result
+
+			continue
+		}
+
+		select {
+		case p.results <- result:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (p *Pool[In, Out]) process(ctx context.Context, job *poolJob[In, Out]) *PoolResult[In, Out] {
+	if p.config.JobTimeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, p.config.JobTimeout)
+		defer cancel()
+	}
+
+	output, err := p.fn(ctx, job.input)
+
+	return &PoolResult[In, Out]{
+		Index:  job.index,
+		Input:  job.input,
+		Output: output,
+		Err:    err,
+	}
+}
+
+// emitOrdered sends the results in the order the jobs were submitted.
+func (p *Pool[In, Out]) emitOrdered(ctx context.Context) {
+	for job := range p.pending {
+		var result *PoolResult[In, Out]
+
+		select {
+		case result = <-job.result:
+		case <-ctx.Done():
+			return
+		}
+
+		if result == nil {
+			continue
+		}
+
+		select {
+		case p.results <- result:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2026 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task_test
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sumup-oss/go-pkgs/task"
+)
+
+func TestPool(t *testing.T) {
+	itoa := func(ctx context.Context, input int) (string, error) {
+		return strconv.Itoa(input), nil
+	}
+
+	t.Run("when run in a group, it processes all the submitted jobs", func(t *testing.T) {
+		t.Parallel()
+
+		pool := task.NewPool(&task.PoolConfig{Workers: 3}, itoa)
+
+		group := task.NewGroup()
+		group.Go(pool.Run)
+		group.Go(func(ctx context.Context) error {
+			defer pool.Close()
+
+			for i := 0; i < 10; i++ {
+				err := pool.Submit(ctx, i)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+
+		var outputs []string
+		for result := range pool.Results() {
+			require.NoError(t, result.Err)
+			assert.Equal(t, strconv.Itoa(result.Input), result.Output)
+			outputs = append(outputs, result.Output)
+		}
+
+		require.NoError(t, group.Wait(context.Background()))
+
+		sort.Slice(outputs, func(i, j int) bool {
+			a, _ := strconv.Atoi(outputs[i])
+			b, _ := strconv.Atoi(outputs[j])
+
+			return a < b
+		})
+		assert.Equal(t, []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"}, outputs)
+	})
+
+	t.Run("when ordered, it returns the results in the submission order", func(t *testing.T) {
+		t.Parallel()
+
+		pool := task.NewPool(
+			&task.PoolConfig{Workers: 4, Ordered: true},
+			func(ctx context.Context, input int) (int, error) {
+				// the earlier jobs finish later
+				time.Sleep(time.Duration(10-input) * time.Millisecond)
+
+				return input * 2, nil
+			},
+		)
+
+		go func() {
+			_ = pool.Run(context.Background())
+		}()
+
+		go func() {
+			defer pool.Close()
+
+			for i := 0; i < 10; i++ {
+				_ = pool.Submit(context.Background(), i)
+			}
+		}()
+
+		var indexes, outputs []int
+		for result := range pool.Results() {
+			indexes = append(indexes, result.Index)
+			outputs = append(outputs, result.Output)
+		}
+
+		assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, indexes)
+		assert.Equal(t, []int{0, 2, 4, 6, 8, 10, 12, 14, 16, 18}, outputs)
+	})
+
+	t.Run("when the queue is full, Submit blocks until the context is done", func(t *testing.T) {
+		t.Parallel()
+
+		pool := task.NewPool(&task.PoolConfig{Workers: 1, QueueSize: 2}, itoa)
+
+		require.NoError(t, pool.Submit(context.Background(), 1))
+		require.NoError(t, pool.Submit(context.Background(), 2))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		assert.Equal(t, context.DeadlineExceeded, pool.Submit(ctx, 3))
+	})
+
+	t.Run("when the job timeout is exceeded, it cancels the job context", func(t *testing.T) {
+		t.Parallel()
+
+		pool := task.NewPool(
+			&task.PoolConfig{Workers: 1, JobTimeout: time.Millisecond},
+			func(ctx context.Context, input int) (int, error) {
+				<-ctx.Done()
+
+				return 0, ctx.Err()
+			},
+		)
+
+		go func() {
+			_ = pool.Run(context.Background())
+		}()
+
+		require.NoError(t, pool.Submit(context.Background(), 1))
+		pool.Close()
+
+		result := <-pool.Results()
+		assert.Equal(t, context.DeadlineExceeded, result.Err)
+
+		_, ok := <-pool.Results()
+		assert.False(t, ok)
+	})
+
+	t.Run("when the context is done, it stops and closes the results", func(t *testing.T) {
+		t.Parallel()
+
+		pool := task.NewPool(
+			&task.PoolConfig{Workers: 2, Ordered: true},
+			func(ctx context.Context, input int) (int, error) {
+				<-ctx.Done()
+
+				return 0, ctx.Err()
+			},
+		)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		runErr := make(chan error, 1)
+
+		go func() {
+			runErr <- pool.Run(ctx)
+		}()
+
+		require.NoError(t, pool.Submit(context.Background(), 1))
+		cancel()
+
+		require.NoError(t, <-runErr)
+
+		for range pool.Results() {
+		}
+
+		assert.Equal(t, task.ErrPoolClosed, pool.Submit(context.Background(), 2))
+	})
+}
@@ -0,0 +1,192 @@
+// Copyright 2026 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sumup-oss/go-pkgs/backoff"
+)
+
+// RateLimiterConfig is the configuration of RateLimiter.
+type RateLimiterConfig struct {
+	// Rate is how many tokens are added to the bucket per second.
+	Rate float64
+	// Burst is the size of the bucket, meaning how many tokens can be taken at once.
+	// The default is 1.
+	Burst int
+	// Clock is used for measuring the time, which allows faking the time in tests.
+	// The default is backoff.SystemClock.
+	Clock backoff.Clock
+}
+
+// RateLimiter is a token bucket rate limiter.
+//
+// The bucket starts full. It is safe for concurrent use, so the same RateLimiter can be shared by
+// multiple tasks to limit them together.
+type RateLimiter struct {
+	config *RateLimiterConfig
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates RateLimiter instance.
+//
+// NewRateLimiter panics if the config Rate is not positive.
+func NewRateLimiter(config *RateLimiterConfig) *RateLimiter {
+	if config.Rate <= 0 {
+		panic("task: non-positive rate for NewRateLimiter")
+	}
+
+	if config.Burst == 0 {
+		config.Burst = 1
+	}
+
+	if config.Clock == nil {
+		config.Clock = backoff.SystemClock{}
+	}
+
+	return &RateLimiter{
+		config: config,
+		tokens: float64(config.Burst),
+		last:   config.Clock.Now(),
+	}
+}
+
+// Allow reports if a token can be taken now, taking it if so.
+func (l *RateLimiter) Allow() bool {
+	return l.AllowN(1)
+}
+
+// AllowN reports if n tokens can be taken now, taking them if so.
+func (l *RateLimiter) AllowN(n int) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill()
+
+	if l.tokens < float64(n) {
+		return false
+	}
+
+	l.tokens -= float64(n)
+
+	return true
+}
+
+// Wait blocks until a token can be taken, or the context is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	return l.WaitN(ctx, 1)
+}
+
+// WaitN blocks until n tokens can be taken, or the context is done.
+//
+// The tokens are reserved upfront, so waiting callers are served in order. When the context
+// is done, the reserved tokens are given back and the context error is returned.
+// It returns error if n exceeds the Burst, since the tokens could never be taken.
+func (l *RateLimiter) WaitN(ctx context.Context, n int) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	delay, err := l.reserve(n)
+	if err != nil {
+		return err
+	}
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := l.config.Clock.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		l.cancel(n)
+
+		return ctx.Err()
+	case <-timer.C():
+		return nil
+	}
+}
+
+// reserve takes n tokens, even if the bucket goes into debt, and returns how long to wait until
+// the debt is paid.
+func (l *RateLimiter) reserve(n int) (time.Duration, error) {
+	if n > l.config.Burst {
+		return 0, fmt.Errorf("rate limiter: %d tokens exceed the burst %d", n, l.config.Burst)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill()
+	l.tokens -= float64(n)
+
+	if l.tokens >= 0 {
+		return 0, nil
+	}
+
+	return time.Duration(-l.tokens / l.config.Rate * float64(time.Second)), nil
+}
+
+func (l *RateLimiter) cancel(n int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill()
+	l.tokens = min(l.tokens+float64(n), float64(l.config.Burst))
+}
+
+// refill adds the tokens for the time passed since the last refill.
+func (l *RateLimiter) refill() {
+	now := l.config.Clock.Now()
+	elapsed := now.Sub(l.last)
+	l.last = now
+
+	if elapsed <= 0 {
+		return
+	}
+
+	l.tokens = min(l.tokens+elapsed.Seconds()*l.config.Rate, float64(l.config.Burst))
+}
+
+// RateLimit returns a TaskFuncDecorator that waits for a token of the limiter before every run
+// of the task.
+//
+// When the context is done while waiting, the task is not run and the context error is returned.
+func RateLimit(limiter *RateLimiter) TaskFuncDecorator {
+	return RateLimitN(limiter, 1)
+}
+
+// RateLimitN works like RateLimit, but every run of the task takes n tokens.
+func RateLimitN(limiter *RateLimiter, n int) TaskFuncDecorator {
+	return func(fn TaskFunc) TaskFunc {
+		return func(ctx context.Context) error {
+			err := limiter.WaitN(ctx, n)
+			if err != nil {
+				return err
+			}
+
+			return fn(ctx)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sumup-oss/go-pkgs/backoff/backofftest"
+	"github.com/sumup-oss/go-pkgs/task"
+)
+
+func TestRateLimiter(t *testing.T) {
+	t.Run("it allows the burst and then a token per rate interval", func(t *testing.T) {
+		t.Parallel()
+
+		clock := backofftest.NewFakeClock(time.Now())
+		limiter := task.NewRateLimiter(&task.RateLimiterConfig{Rate: 2, Burst: 3, Clock: clock})
+
+		assert.True(t, limiter.Allow())
+		assert.True(t, limiter.AllowN(2))
+		assert.False(t, limiter.Allow())
+
+		clock.Advance(500 * time.Millisecond)
+		assert.True(t, limiter.Allow())
+		assert.False(t, limiter.Allow())
+
+		clock.Advance(time.Hour)
+		assert.True(t, limiter.AllowN(3))
+		assert.False(t, limiter.Allow())
+	})
+
+	t.Run("when there are not enough tokens, Wait sleeps until there are", func(t *testing.T) {
+		t.Parallel()
+
+		clock := backofftest.NewAutoAdvanceFakeClock(time.Now())
+		limiter := task.NewRateLimiter(&task.RateLimiterConfig{Rate: 4, Burst: 2, Clock: clock})
+
+		for i := 0; i < 3; i++ {
+			require.NoError(t, limiter.Wait(context.Background()))
+		}
+
+		require.NoError(t, limiter.WaitN(context.Background(), 2))
+
+		assert.Equal(t, []time.Duration{250 * time.Millisecond, 500 * time.Millisecond}, clock.Sleeps())
+	})
+
+	t.Run("when the context is done while waiting, it gives the tokens back", func(t *testing.T) {
+		t.Parallel()
+
+		clock := backofftest.NewFakeClock(time.Now())
+		limiter := task.NewRateLimiter(&task.RateLimiterConfig{Rate: 1, Burst: 1, Clock: clock})
+		require.True(t, limiter.Allow())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		errCh := make(chan error, 1)
+
+		go func() {
+			errCh <- limiter.Wait(ctx)
+		}()
+
+		clock.WaitForTimers(1)
+		cancel()
+
+		assert.Equal(t, context.Canceled, <-errCh)
+
+		clock.Advance(time.Second)
+		assert.True(t, limiter.Allow())
+	})
+
+	t.Run("when the tokens exceed the burst, it returns error", func(t *testing.T) {
+		t.Parallel()
+
+		limiter := task.NewRateLimiter(&task.RateLimiterConfig{Rate: 1, Burst: 2})
+
+		assert.EqualError(t, limiter.WaitN(context.Background(), 3), "rate limiter: 3 tokens exceed the burst 2")
+	})
+
+	t.Run("when used as a decorator, it limits the task runs", func(t *testing.T) {
+		t.Parallel()
+
+		clock := backofftest.NewAutoAdvanceFakeClock(time.Now())
+		limiter := task.NewRateLimiter(&task.RateLimiterConfig{Rate: 10, Burst: 2, Clock: clock})
+
+		runs := 0
+		fn := task.NewTaskFunc(
+			func(ctx context.Context) error {
+				runs++
+
+				return nil
+			},
+			task.RateLimitN(limiter, 2),
+		)
+
+		for i := 0; i < 3; i++ {
+			require.NoError(t, fn(context.Background()))
+		}
+
+		assert.Equal(t, 3, runs)
+		assert.Equal(t, []time.Duration{200 * time.Millisecond, 200 * time.Millisecond}, clock.Sleeps())
+	})
+}
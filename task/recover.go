@@ -0,0 +1,81 @@
+// Copyright 2026 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/sumup-oss/go-pkgs/errors"
+)
+
+// PanicError is returned by a task decorated with Recover, when the task panics.
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value interface{}
+	// Stack is the stack trace of the panicking goroutine.
+	Stack []byte
+}
+
+// Error returns the error message.
+func (err *PanicError) Error() string {
+	return fmt.Sprintf("task panicked: %v", err.Value)
+}
+
+// Recover is a TaskFuncDecorator that recovers panics in the task and returns them as PanicError.
+//
+// When the panic value is an error, it is wrapped by the PanicError, so errors.Is and errors.As can
+// find it.
+//
+// This way a panicking task in a Group cancels the other tasks like any other failing task, instead
+// of crashing the process.
+//
+// Example:
+//
+//	group.Go(task.NewTaskFunc(consumer.Run, task.Recover))
+//
+//	err := group.Wait(ctx)
+//
+//	var panicErr *task.PanicError
+//	if errors.As(err, &panicErr) {
+//		log.Error("consumer panicked", zap.Error(err), zap.ByteString("stack", panicErr.Stack))
+//	}
+func Recover(fn TaskFunc) TaskFunc {
+	return func(ctx context.Context) (err error) {
+		defer func() {
+			value := recover()
+			if value != nil {
+				err = newPanicError(value)
+			}
+		}()
+
+		return fn(ctx)
+	}
+}
+
+func newPanicError(value interface{}) error {
+	panicErr := &PanicError{
+		Value: value,
+		Stack: debug.Stack(),
+	}
+
+	cause, ok := value.(error)
+	if ok {
+		return errors.WrapError(cause, panicErr)
+	}
+
+	return errors.Propagate(panicErr)
+}
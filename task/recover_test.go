@@ -0,0 +1,92 @@
+// Copyright 2026 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sumup-oss/go-pkgs/errors"
+	"github.com/sumup-oss/go-pkgs/task"
+)
+
+func TestRecover(t *testing.T) {
+	t.Run("when the task panics, it returns PanicError with the value and the stack", func(t *testing.T) {
+		t.Parallel()
+
+		fn := task.NewTaskFunc(
+			func(ctx context.Context) error {
+				panic("boom")
+			},
+			task.Recover,
+		)
+
+		err := fn(context.Background())
+
+		var panicErr *task.PanicError
+		require.True(t, errors.As(err, &panicErr))
+		assert.Equal(t, "boom", panicErr.Value)
+		assert.Contains(t, string(panicErr.Stack), "recover_test.go")
+		assert.EqualError(t, err, "task panicked: boom")
+	})
+
+	t.Run("when the task panics with an error, the error is in the chain", func(t *testing.T) {
+		t.Parallel()
+
+		fn := task.Recover(func(ctx context.Context) error {
+			panic(assert.AnError)
+		})
+
+		err := fn(context.Background())
+
+		assert.True(t, errors.Is(err, assert.AnError))
+
+		var panicErr *task.PanicError
+		assert.True(t, errors.As(err, &panicErr))
+	})
+
+	t.Run("when the task does not panic, it returns the task error", func(t *testing.T) {
+		t.Parallel()
+
+		fn := task.Recover(func(ctx context.Context) error {
+			return assert.AnError
+		})
+
+		assert.Equal(t, assert.AnError, fn(context.Background()))
+	})
+
+	t.Run("when a task in a group panics, it cancels the other tasks", func(t *testing.T) {
+		t.Parallel()
+
+		group := task.NewGroup()
+		foo := NewTestTask(nil)
+
+		group.Go(foo.Run)
+		<-foo.RunReady
+
+		group.Go(task.Recover(func(ctx context.Context) error {
+			panic("boom")
+		}))
+
+		err := group.Wait(context.Background())
+
+		var panicErr *task.PanicError
+		assert.True(t, errors.As(err, &panicErr))
+		assert.Equal(t, 1, foo.StopCount)
+	})
+}
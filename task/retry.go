@@ -27,14 +27,11 @@ type RetryableError interface {
 }
 
 // IsRetryableError checks if the error is retryable.
+//
+// The error chain is walked, so the error can be wrapped, e.g. with errors.Wrap or
+// stacktrace.Propagate. The first error in the chain implementing RetryableError decides.
 func IsRetryableError(err error) bool {
-	if err == nil {
-		return false
-	}
-
-	retryableErr, ok := err.(RetryableError)
-
-	return ok && retryableErr.IsRetryable()
+	return IsRetryableErrorWith(err)
 }
 
 // retryableError implements the RetryableError interface.
@@ -57,23 +54,7 @@ func NewRetryableError(err error) error {
 // returns true.
 // The retryInterval specify how much time to wait between every retry.
 func Retry(retryInterval time.Duration, retryFunc TaskFunc) TaskFunc {
-	return func(ctx context.Context) error {
-		for {
-			err := retryFunc(ctx)
-			if !IsRetryableError(err) {
-				return err
-			}
-
-			retryTimer := time.NewTimer(retryInterval)
-			select {
-			case <-ctx.Done():
-				retryTimer.Stop()
-
-				return nil
-			case <-retryTimer.C:
-			}
-		}
-	}
+	return RetryWithOptions(retryFunc, WithInterval(retryInterval))
 }
 
 // MaxRetryExceedError is returned when RetryUntil could not complete successfully
@@ -101,6 +82,11 @@ func (err *MaxRetryExceedError) Cause() error {
 	return err.lastErr
 }
 
+// IsRetryable returns false, because the retry attempts are already exhausted.
+func (err *MaxRetryExceedError) IsRetryable() bool {
+	return false
+}
+
 // RetryUntil retries a task maxAttempts times until it returns no error or the returned error is non retriable.
 // An error is retriable when it implements the RetryableError interface and its IsRetryable method
 // returns true.
@@ -110,32 +96,7 @@ func (err *MaxRetryExceedError) Cause() error {
 // NOTE: when the cancel channel is closed, RetryUntil will not return an error, even if
 // the retryFunc had failed couple of times so far.
 func RetryUntil(maxAttempts int, retryInterval time.Duration, retryFunc TaskFunc) TaskFunc {
-	return func(ctx context.Context) error {
-		var err error
-		attempts := maxAttempts
-
-		for {
-			if attempts < 1 {
-				return NewMaxRetryError(maxAttempts, err)
-			}
-
-			err = retryFunc(ctx)
-			if !IsRetryableError(err) {
-				return err
-			}
-
-			attempts -= 1
-
-			retryTimer := time.NewTimer(retryInterval)
-			select {
-			case <-ctx.Done():
-				retryTimer.Stop()
-
-				return nil
-			case <-retryTimer.C:
-			}
-		}
-	}
+	return RetryWithOptions(retryFunc, WithMaxAttempts(maxAttempts), WithInterval(retryInterval))
 }
 
 // DeadlineRetryError is returned when RetryWithDeadline could not complete successfully
@@ -163,6 +124,46 @@ func (err *DeadlineRetryError) Cause() error {
 	return err.lastErr
 }
 
+// IsRetryable returns false, because the retry deadline is already exceeded.
+func (err *DeadlineRetryError) IsRetryable() bool {
+	return false
+}
+
+// CanceledRetryError is returned when the context is done while retrying and
+// the WithCanceledError option is used.
+type CanceledRetryError struct {
+	ctxErr  error
+	lastErr error
+}
+
+// NewCanceledRetryError creates CanceledRetryError instance.
+func NewCanceledRetryError(ctxErr, lastErr error) error {
+	return &CanceledRetryError{
+		ctxErr:  ctxErr,
+		lastErr: lastErr,
+	}
+}
+
+// Error returns the error message.
+func (err *CanceledRetryError) Error() string {
+	return fmt.Sprintf("retry canceled: %v, last err: %v", err.ctxErr, err.lastErr)
+}
+
+// Cause returns the last error before the context is done.
+func (err *CanceledRetryError) Cause() error {
+	return err.lastErr
+}
+
+// IsRetryable returns false, because the retrying was canceled.
+func (err *CanceledRetryError) IsRetryable() bool {
+	return false
+}
+
+// Unwrap returns the context error and the last error.
+func (err *CanceledRetryError) Unwrap() []error {
+	return []error{err.ctxErr, err.lastErr}
+}
+
 // RetryWithDeadline retries a task until it returns no error, or the returned error is non retriable,
 // or timeoutDeadline is exceeded.
 // An error is retriable when it implements the RetryableError interface and its IsRetryable method
@@ -178,98 +179,80 @@ func RetryWithDeadline(
 	retryInterval time.Duration,
 	retryFunc TaskFunc,
 ) TaskFunc {
-	return func(ctx context.Context) error {
-		// when the task is complete or fails with non retryable error, doneChan is closed
-		// for signaling the deadline monitoring to stop
-		doneChan := make(chan struct{})
-		// when the external context is canceled or the deadline is exceeded retryCtx
-		// is canceled for signaling the task and retryTimer to cancel.
-		retryCtx, cancel := context.WithCancel(ctx)
-		defer cancel()
-
-		var err error
-		var lastErr error // last non-nil error
-		go func() {
-			defer close(doneChan)
-
-			for {
-				err = retryFunc(retryCtx)
-				if err != nil {
-					lastErr = err
-				}
-				if !IsRetryableError(err) {
-					return
-				}
-
-				retryTimer := time.NewTimer(retryInterval)
-				select {
-				case <-retryCtx.Done():
-					retryTimer.Stop()
-					err = nil
-
-					return
-				case <-retryTimer.C:
-				}
-			}
-		}()
-
-		deadlineTimer := time.NewTimer(timeoutDeadline)
-		defer deadlineTimer.Stop()
-
-		select {
-		case <-doneChan:
-			return err
-		case <-deadlineTimer.C:
-			cancel()
-			<-doneChan
-
-			return NewDeadlineError(timeoutDeadline, lastErr)
-		}
-	}
+	return RetryWithOptions(retryFunc, WithDeadline(timeoutDeadline), WithInterval(retryInterval))
 }
 
+// Backoff calculates the durations to wait between retries.
+//
+// When Next returns backoff.Stop, no more retries are made.
+//
+// If the Backoff also implements Sleep(ctx, d) error, like backoff.Backoff does, the sleeping is
+// delegated to it, which allows faking the time in tests.
 type Backoff interface {
 	Next() time.Duration
 }
 
+type sleeper interface {
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+// sleepBackoff sleeps for the duration d, delegating to the backoff when it implements sleeper.
+//
+// It returns false if the context is done before the sleep is over.
+func sleepBackoff(ctx context.Context, retryBackoff Backoff, d time.Duration) bool {
+	s, ok := retryBackoff.(sleeper)
+	if ok {
+		return s.Sleep(ctx, d) == nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
 // RetryWithBackoff retries a task maxAttempts times with exponential backoff until it returns
 // no error or the returned error is non retriable.
 //
 // An error is retriable when it implements the RetryableError interface and its IsRetryable method
 // returns true.
 //
-// If the task do not complete for maxAttempts retries, RetryWithBackoff will return MaxRetryExceedError.
+// When the error implements RetryAfterError, e.g. created by NewRetryableErrorWithDelay,
+// the suggested delay is used instead of the backoff duration.
+//
+// If the task do not complete for maxAttempts retries, or the backoff returns backoff.Stop,
+// RetryWithBackoff will return MaxRetryExceedError.
 //
 // NOTE: when the cancel channel is closed, RetryWithBackoff will not return an error, even if
 // the retryFunc had failed couple of times so far.
 //
 // If maxAttempts is -1, it will retry infinitely.
-func RetryWithBackoff(maxAttempts int, backoff Backoff, retryFunc TaskFunc) TaskFunc {
-	return func(ctx context.Context) error {
-		var err error
-		attempts := 0
-
-		for {
-			err = retryFunc(ctx)
-			if !IsRetryableError(err) {
-				return err
-			}
-
-			if maxAttempts != -1 {
-				attempts++
-				if attempts >= maxAttempts {
-					return NewMaxRetryError(maxAttempts, err)
-				}
-			}
-
-			retryTimer := time.NewTimer(backoff.Next())
-			select {
-			case <-ctx.Done():
-				retryTimer.Stop()
-
-				return nil
-			case <-retryTimer.C:
-			}
-		}
+func RetryWithBackoff(maxAttempts int, retryBackoff Backoff, retryFunc TaskFunc) TaskFunc {
+	return RetryWithBackoffAndBudget(maxAttempts, retryBackoff, nil, retryFunc)
+}
+
+// RetryWithBackoffAndBudget works like RetryWithBackoff, but every retry is withdrawn from
+// the budget first.
+//
+// When the budget is exhausted, RetryWithBackoffAndBudget will return RetryBudgetExceededError.
+//
+// If budget is nil, the retries are not limited by a budget.
+func RetryWithBackoffAndBudget(
+	maxAttempts int,
+	retryBackoff Backoff,
+	budget RetryBudget,
+	retryFunc TaskFunc,
+) TaskFunc {
+	opts := []RetryOption{WithBackoff(retryBackoff), WithRetryBudget(budget)}
+	if maxAttempts != -1 {
+		// the task is always run at least once
+		opts = append(opts, WithMaxAttempts(max(maxAttempts, 1)))
 	}
+
+	return RetryWithOptions(retryFunc, opts...)
 }
@@ -0,0 +1,102 @@
+// Copyright 2026 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryAfterError is an error suggesting how long to wait before retrying,
+// e.g. from an HTTP Retry-After header.
+type RetryAfterError interface {
+	error
+	RetryAfter() time.Duration
+}
+
+// retryableErrorWithDelay implements the RetryableError and RetryAfterError interfaces.
+type retryableErrorWithDelay struct {
+	error
+	delay time.Duration
+}
+
+// IsRetryable verify that the error is in fact retryable.
+func (err *retryableErrorWithDelay) IsRetryable() bool {
+	return true
+}
+
+// RetryAfter returns the suggested delay before retrying.
+func (err *retryableErrorWithDelay) RetryAfter() time.Duration {
+	return err.delay
+}
+
+// NewRetryableErrorWithDelay wraps an error and makes it retryable after the delay.
+//
+// The retry functions wait for the delay instead of the backoff duration.
+func NewRetryableErrorWithDelay(err error, delay time.Duration) error {
+	return &retryableErrorWithDelay{
+		error: err,
+		delay: delay,
+	}
+}
+
+// RetryAfter returns the delay suggested by the first RetryAfterError in the error chain.
+//
+// The chain is walked the same way as by IsRetryableErrorWith, so the delay is found also in
+// errors wrapped with stacktrace.Propagate.
+func RetryAfter(err error) (time.Duration, bool) {
+	var retryAfterErr RetryAfterError
+
+	found := walkErrorChain(err, func(err error) bool {
+		var ok bool
+		retryAfterErr, ok = err.(RetryAfterError)
+
+		return ok
+	})
+	if !found {
+		return 0, false
+	}
+
+	return max(retryAfterErr.RetryAfter(), 0), true
+}
+
+// ParseRetryAfter parses the value of an HTTP Retry-After header, which is either a number of
+// seconds or an HTTP date.
+//
+// It returns false if the value can't be parsed. Dates in the past result in zero delay.
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	return max(date.Sub(now), 0), true
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/palantir/stacktrace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sumup-oss/go-pkgs/backoff"
+	"github.com/sumup-oss/go-pkgs/backoff/backofftest"
+	"github.com/sumup-oss/go-pkgs/task"
+)
+
+func TestNewRetryableErrorWithDelay(t *testing.T) {
+	t.Run("it is retryable and suggests the delay", func(t *testing.T) {
+		t.Parallel()
+
+		err := task.NewRetryableErrorWithDelay(assert.AnError, time.Minute)
+
+		assert.True(t, task.IsRetryableError(err))
+		assert.EqualError(t, err, assert.AnError.Error())
+
+		delay, ok := task.RetryAfter(err)
+		assert.True(t, ok)
+		assert.Equal(t, time.Minute, delay)
+	})
+
+	t.Run("when the error is wrapped, RetryAfter still finds the delay", func(t *testing.T) {
+		t.Parallel()
+
+		retryErr := task.NewRetryableErrorWithDelay(assert.AnError, 5*time.Second)
+
+		for _, err := range []error{
+			fmt.Errorf("foo: %w", retryErr),
+			stacktrace.Propagate(retryErr, "foo"),
+			stacktrace.Propagate(stacktrace.Propagate(retryErr, "foo"), "bar"),
+		} {
+			delay, ok := task.RetryAfter(err)
+			assert.True(t, ok)
+			assert.Equal(t, 5*time.Second, delay)
+		}
+	})
+
+	t.Run("when the error does not suggest a delay, RetryAfter returns false", func(t *testing.T) {
+		t.Parallel()
+
+		_, ok := task.RetryAfter(task.NewRetryableError(assert.AnError))
+		assert.False(t, ok)
+	})
+
+	t.Run("when the task returns it, the retry waits for the delay instead of the backoff", func(t *testing.T) {
+		t.Parallel()
+
+		clock := backofftest.NewAutoAdvanceFakeClock(time.Now())
+		cnt := 0
+		repeat := task.RetryWithBackoff(
+			10,
+			backoff.NewBackoff(&backoff.Config{Base: time.Second, Jitter: backoff.NoJitter, Clock: clock}),
+			func(ctx context.Context) error {
+				cnt++
+				switch cnt {
+				case 1:
+					return task.NewRetryableError(errors.New("fooErr"))
+				case 2:
+					return task.NewRetryableErrorWithDelay(errors.New("barErr"), time.Minute)
+				default:
+					return nil
+				}
+			},
+		)
+
+		err := repeat(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, []time.Duration{time.Second, time.Minute}, clock.Sleeps())
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	for _, tc := range []struct {
+		name          string
+		value         string
+		expectedDelay time.Duration
+		expectedOK    bool
+	}{
+		{name: "seconds", value: "120", expectedDelay: 2 * time.Minute, expectedOK: true},
+		{name: "http date", value: "Fri, 02 Jan 2026 15:05:05 GMT", expectedDelay: time.Minute, expectedOK: true},
+		{name: "http date in the past", value: "Fri, 02 Jan 2026 15:00:00 GMT", expectedDelay: 0, expectedOK: true},
+		{name: "negative seconds", value: "-1", expectedOK: false},
+		{name: "empty", value: "", expectedOK: false},
+		{name: "invalid", value: "soon", expectedOK: false},
+	} {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			delay, ok := task.ParseRetryAfter(tc.value, now)
+
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedDelay, delay)
+		})
+	}
+}
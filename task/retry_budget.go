@@ -0,0 +1,151 @@
+// Copyright 2026 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sumup-oss/go-pkgs/backoff"
+)
+
+const (
+	defaultRetryBudgetMaxTokens           = 10
+	defaultRetryBudgetMinRetriesPerSecond = 0.1
+)
+
+// RetryBudget limits the number of retries, so a fleet of failing clients does not turn into
+// a retry storm against the dependency.
+//
+// A single RetryBudget is supposed to be shared by all the retry loops calling the same dependency.
+type RetryBudget interface {
+	// Deposit records a new call, which is not a retry.
+	Deposit()
+	// Withdraw takes a single retry from the budget.
+	// It returns false when the budget is exhausted, meaning that the retry must not be made.
+	Withdraw() bool
+}
+
+// RetryBudgetExceededError is returned when a retry is not made, because the RetryBudget
+// is exhausted.
+type RetryBudgetExceededError struct {
+	lastErr error
+}
+
+// NewRetryBudgetExceededError creates RetryBudgetExceededError instance.
+func NewRetryBudgetExceededError(lastErr error) error {
+	return &RetryBudgetExceededError{
+		lastErr: lastErr,
+	}
+}
+
+// Error returns the error message.
+func (err *RetryBudgetExceededError) Error() string {
+	return fmt.Sprintf("retry budget exceeded, last err: %v", err.lastErr)
+}
+
+// Cause returns the last error before the retry budget was exceeded.
+func (err *RetryBudgetExceededError) Cause() error {
+	return err.lastErr
+}
+
+// IsRetryable returns false, because retrying again would exceed the budget.
+func (err *RetryBudgetExceededError) IsRetryable() bool {
+	return false
+}
+
+// RetryBudgetConfig is used for the TokenBucketRetryBudget constructor.
+type RetryBudgetConfig struct {
+	// RetryRatio is the number of tokens deposited by every call, e.g. 0.1 means that the retries
+	// can be at most 10% of the calls.
+	RetryRatio float64
+	// MinRetriesPerSecond is the number of tokens added every second regardless of the calls,
+	// so the clients with low traffic are still able to retry, and the retries made long ago do not
+	// exhaust the budget forever. Defaults to 0.1, i.e. a retry every 10 seconds.
+	MinRetriesPerSecond float64
+	// MaxTokens is the maximum number of tokens the bucket can hold, i.e. the maximum burst of
+	// retries. Defaults to 10. The bucket starts full.
+	MaxTokens float64
+	// Clock is used for the MinRetriesPerSecond refill. Defaults to backoff.SystemClock.
+	Clock backoff.Clock
+}
+
+// TokenBucketRetryBudget is a RetryBudget implemented with a token bucket.
+//
+// Every call deposits RetryRatio tokens, every retry withdraws a single token.
+//
+// It is safe to be used in multiple go routines.
+type TokenBucketRetryBudget struct {
+	config *RetryBudgetConfig
+
+	mu         sync.Mutex
+	tokens     float64
+	lastRefill time.Time
+}
+
+// NewTokenBucketRetryBudget creates TokenBucketRetryBudget instance.
+func NewTokenBucketRetryBudget(config *RetryBudgetConfig) *TokenBucketRetryBudget {
+	if config.MaxTokens == 0 {
+		config.MaxTokens = defaultRetryBudgetMaxTokens
+	}
+
+	if config.MinRetriesPerSecond == 0 {
+		config.MinRetriesPerSecond = defaultRetryBudgetMinRetriesPerSecond
+	}
+
+	if config.Clock == nil {
+		config.Clock = backoff.SystemClock{}
+	}
+
+	return &TokenBucketRetryBudget{
+		config:     config,
+		tokens:     config.MaxTokens,
+		lastRefill: config.Clock.Now(),
+	}
+}
+
+// Deposit adds RetryRatio tokens to the bucket.
+func (b *TokenBucketRetryBudget) Deposit() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.add(b.config.RetryRatio)
+}
+
+// Withdraw takes a single token from the bucket, if there is one.
+func (b *TokenBucketRetryBudget) Withdraw() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.config.Clock.Now()
+	b.add(now.Sub(b.lastRefill).Seconds() * b.config.MinRetriesPerSecond)
+	b.lastRefill = now
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+
+	return true
+}
+
+func (b *TokenBucketRetryBudget) add(tokens float64) {
+	b.tokens += tokens
+	if b.tokens > b.config.MaxTokens {
+		b.tokens = b.config.MaxTokens
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2026 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sumup-oss/go-pkgs/backoff"
+	"github.com/sumup-oss/go-pkgs/backoff/backofftest"
+	"github.com/sumup-oss/go-pkgs/task"
+)
+
+func TestTokenBucketRetryBudget(t *testing.T) {
+	t.Run("it allows retries until the bucket is empty", func(t *testing.T) {
+		t.Parallel()
+
+		budget := task.NewTokenBucketRetryBudget(&task.RetryBudgetConfig{
+			MaxTokens: 2,
+			Clock:     backofftest.NewFakeClock(time.Now()),
+		})
+
+		assert.True(t, budget.Withdraw())
+		assert.True(t, budget.Withdraw())
+		assert.False(t, budget.Withdraw())
+	})
+
+	t.Run("it adds RetryRatio tokens for every deposit", func(t *testing.T) {
+		t.Parallel()
+
+		budget := task.NewTokenBucketRetryBudget(&task.RetryBudgetConfig{
+			RetryRatio: 0.5,
+			MaxTokens:  1,
+			Clock:      backofftest.NewFakeClock(time.Now()),
+		})
+
+		assert.True(t, budget.Withdraw())
+		budget.Deposit()
+		assert.False(t, budget.Withdraw())
+		budget.Deposit()
+		assert.True(t, budget.Withdraw())
+	})
+
+	t.Run("it adds MinRetriesPerSecond tokens with the time", func(t *testing.T) {
+		t.Parallel()
+
+		clock := backofftest.NewFakeClock(time.Now())
+		budget := task.NewTokenBucketRetryBudget(&task.RetryBudgetConfig{
+			MinRetriesPerSecond: 2,
+			MaxTokens:           1,
+			Clock:               clock,
+		})
+
+		assert.True(t, budget.Withdraw())
+		assert.False(t, budget.Withdraw())
+
+		clock.Advance(time.Second / 2)
+		assert.True(t, budget.Withdraw())
+	})
+
+	t.Run("when MinRetriesPerSecond is not set, it still refills the bucket with the time", func(t *testing.T) {
+		t.Parallel()
+
+		clock := backofftest.NewFakeClock(time.Now())
+		budget := task.NewTokenBucketRetryBudget(&task.RetryBudgetConfig{
+			MaxTokens: 1,
+			Clock:     clock,
+		})
+
+		assert.True(t, budget.Withdraw())
+		assert.False(t, budget.Withdraw())
+
+		clock.Advance(10 * time.Second)
+		assert.True(t, budget.Withdraw())
+	})
+}
+
+func TestRetryWithBackoffAndBudget(t *testing.T) {
+	t.Run(
+		"when the budget is exhausted, it stops retrying and returns RetryBudgetExceededError",
+		func(t *testing.T) {
+			t.Parallel()
+
+			cnt := 0
+			repeat := task.RetryWithBackoffAndBudget(
+				-1,
+				backoff.NewBackoff(&backoff.Config{Base: 1, Jitter: backoff.NoJitter}),
+				task.NewTokenBucketRetryBudget(&task.RetryBudgetConfig{MaxTokens: 2}),
+				func(ctx context.Context) error {
+					cnt++
+					return task.NewRetryableError(errors.New("fooErr"))
+				},
+			)
+
+			err := repeat(context.Background())
+
+			require.IsType(t, (*task.RetryBudgetExceededError)(nil), err)
+			assert.EqualError(t, err.(*task.RetryBudgetExceededError).Cause(), "fooErr")
+			assert.EqualError(t, err, "retry budget exceeded, last err: fooErr")
+			assert.Equal(t, 3, cnt)
+		},
+	)
+}
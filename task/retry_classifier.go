@@ -0,0 +1,166 @@
+// Copyright 2026 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"reflect"
+	"syscall"
+
+	"github.com/palantir/stacktrace"
+)
+
+var stacktraceErrorType = reflect.TypeOf(stacktrace.NewError(""))
+
+// ErrorClassifier reports if a single error, without the errors it wraps, is retryable.
+//
+// Classifiers are applied to every error in the chain by IsRetryableErrorWith.
+type ErrorClassifier func(err error) bool
+
+// HTTPStatusError is an error carrying the HTTP response status code.
+type HTTPStatusError interface {
+	error
+	StatusCode() int
+}
+
+// IsRetryableErrorWith checks if the error is retryable, walking the error chain.
+//
+// The first error in the chain that implements the RetryableError interface or matches one of
+// the classifiers decides if the error is retryable.
+//
+// The chain is walked with Unwrap() error, Unwrap() []error, Cause() error, As(any) bool, like
+// errors.Propagate and errors.WrapError errors implement it, and stacktrace.RootCause.
+func IsRetryableErrorWith(err error, classifiers ...ErrorClassifier) bool {
+	var retryable bool
+
+	walkErrorChain(err, func(err error) bool {
+		retryableErr, ok := err.(RetryableError)
+		if ok {
+			retryable = retryableErr.IsRetryable()
+
+			return true
+		}
+
+		for _, classifier := range classifiers {
+			if classifier(err) {
+				retryable = true
+
+				return true
+			}
+		}
+
+		return false
+	})
+
+	return retryable
+}
+
+// walkErrorChain calls visit for every error in the chain, depth first, until visit returns true.
+func walkErrorChain(err error, visit func(err error) bool) bool {
+	if err == nil {
+		return false
+	}
+
+	if visit(err) {
+		return true
+	}
+
+	// The wrappers of the errors package keep the wrapping error aside from the chain, so it is
+	// reachable only through their As method, which assigns it to an error target.
+	asWrapper, ok := err.(interface{ As(target any) bool })
+	if ok {
+		var wrapped error
+		if asWrapper.As(&wrapped) && !isSameError(wrapped, err) && walkErrorChain(wrapped, visit) {
+			return true
+		}
+	}
+
+	switch wrapper := err.(type) {
+	case interface{ Unwrap() error }:
+		return walkErrorChain(wrapper.Unwrap(), visit)
+	case interface{ Unwrap() []error }:
+		for _, wrapped := range wrapper.Unwrap() {
+			if walkErrorChain(wrapped, visit) {
+				return true
+			}
+		}
+
+		return false
+	case interface{ Cause() error }:
+		return walkErrorChain(wrapper.Cause(), visit)
+	}
+
+	if reflect.TypeOf(err) == stacktraceErrorType {
+		return walkErrorChain(stacktrace.RootCause(err), visit)
+	}
+
+	return false
+}
+
+func isSameError(err, other error) bool {
+	return reflect.TypeOf(err).Comparable() && err == other
+}
+
+// TransientErrorClassifiers returns the classifiers of the common transient errors:
+// network timeouts, connection resets, HTTP 5xx and 429 status codes and exceeded deadlines.
+func TransientErrorClassifiers() []ErrorClassifier {
+	return []ErrorClassifier{
+		NetTimeoutClassifier,
+		ConnectionResetClassifier,
+		HTTPStatusClassifier,
+		DeadlineExceededClassifier,
+	}
+}
+
+// NetTimeoutClassifier classifies net.Error timeouts as retryable.
+func NetTimeoutClassifier(err error) bool {
+	netErr, ok := err.(net.Error)
+	if !ok {
+		return false
+	}
+
+	return netErr.Timeout()
+}
+
+// ConnectionResetClassifier classifies connection resets and aborts as retryable.
+func ConnectionResetClassifier(err error) bool {
+	errno, ok := err.(syscall.Errno)
+	if !ok {
+		return false
+	}
+
+	return errno == syscall.ECONNRESET || errno == syscall.ECONNABORTED
+}
+
+// HTTPStatusClassifier classifies HTTPStatusError with 5xx or 429 status code as retryable.
+func HTTPStatusClassifier(err error) bool {
+	statusErr, ok := err.(HTTPStatusError)
+	if !ok {
+		return false
+	}
+
+	return statusErr.StatusCode() >= http.StatusInternalServerError ||
+		statusErr.StatusCode() == http.StatusTooManyRequests
+}
+
+// DeadlineExceededClassifier classifies context.DeadlineExceeded as retryable.
+//
+// It is meant for deadlines of sub-calls. When the context of the retry itself is done,
+// the retrying stops anyway.
+func DeadlineExceededClassifier(err error) bool {
+	return err == context.DeadlineExceeded
+}
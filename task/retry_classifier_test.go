@@ -0,0 +1,129 @@
+// Copyright 2026 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/palantir/stacktrace"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sumup-oss/go-pkgs/errors"
+	"github.com/sumup-oss/go-pkgs/task"
+)
+
+type testStatusError struct {
+	statusCode int
+}
+
+func (err *testStatusError) Error() string {
+	return fmt.Sprintf("status %d", err.statusCode)
+}
+
+func (err *testStatusError) StatusCode() int {
+	return err.statusCode
+}
+
+func TestIsRetryableErrorWith(t *testing.T) {
+	t.Run("when the retryable error is wrapped, it returns true", func(t *testing.T) {
+		t.Parallel()
+
+		retryErr := task.NewRetryableError(assert.AnError)
+
+		assert.True(t, task.IsRetryableError(fmt.Errorf("foo: %w", retryErr)))
+		assert.True(t, task.IsRetryableError(errors.Wrap(retryErr, "foo")))
+		assert.True(t, task.IsRetryableError(errors.Propagate(retryErr)))
+		assert.True(t, task.IsRetryableError(errors.WrapError(assert.AnError, retryErr)))
+		assert.True(t, task.IsRetryableError(stacktrace.Propagate(errors.Propagate(retryErr), "foo")))
+		assert.True(t, task.IsRetryableError(stacktrace.Propagate(retryErr, "foo")))
+		assert.True(t, task.IsRetryableError(stacktrace.Propagate(errors.Wrap(retryErr, "foo"), "bar")))
+		assert.True(t, task.IsRetryableError(fmt.Errorf("%w; %w", assert.AnError, retryErr)))
+	})
+
+	t.Run("when the retryable error is the cause of an exhausted retry, it returns false", func(t *testing.T) {
+		t.Parallel()
+
+		err := task.NewMaxRetryError(3, task.NewRetryableError(assert.AnError))
+
+		assert.False(t, task.IsRetryableError(err))
+		assert.False(t, task.IsRetryableErrorWith(err, task.TransientErrorClassifiers()...))
+	})
+
+	t.Run("when the error matches a classifier, it returns true", func(t *testing.T) {
+		t.Parallel()
+
+		classifiers := task.TransientErrorClassifiers()
+
+		assert.True(t, task.IsRetryableErrorWith(
+			&net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)},
+			classifiers...,
+		))
+		assert.True(t, task.IsRetryableErrorWith(
+			errors.Wrap(&net.DNSError{IsTimeout: true}, "foo"),
+			classifiers...,
+		))
+		assert.True(t, task.IsRetryableErrorWith(
+			stacktrace.Propagate(&testStatusError{statusCode: 503}, "foo"),
+			classifiers...,
+		))
+		assert.True(t, task.IsRetryableErrorWith(&testStatusError{statusCode: 429}, classifiers...))
+		assert.True(t, task.IsRetryableErrorWith(
+			errors.WrapError(assert.AnError, &testStatusError{statusCode: 500}),
+			classifiers...,
+		))
+		assert.True(t, task.IsRetryableErrorWith(
+			fmt.Errorf("foo: %w", context.DeadlineExceeded),
+			classifiers...,
+		))
+	})
+
+	t.Run("when the error does not match any classifier, it returns false", func(t *testing.T) {
+		t.Parallel()
+
+		classifiers := task.TransientErrorClassifiers()
+
+		assert.False(t, task.IsRetryableErrorWith(assert.AnError, classifiers...))
+		assert.False(t, task.IsRetryableErrorWith(&testStatusError{statusCode: 404}, classifiers...))
+		assert.False(t, task.IsRetryableErrorWith(context.Canceled, classifiers...))
+		assert.False(t, task.IsRetryableErrorWith(nil, classifiers...))
+	})
+}
+
+func TestRetryWithOptions_WithClassifiers(t *testing.T) {
+	t.Parallel()
+
+	cnt := 0
+	repeat := task.RetryWithOptions(
+		func(ctx context.Context) error {
+			cnt++
+			if cnt < 3 {
+				return stacktrace.Propagate(&testStatusError{statusCode: 502}, "request failed")
+			}
+
+			return nil
+		},
+		task.WithInterval(time.Nanosecond),
+		task.WithClassifiers(task.HTTPStatusClassifier),
+	)
+
+	assert.NoError(t, repeat(context.Background()))
+	assert.Equal(t, 3, cnt)
+}
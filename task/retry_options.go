@@ -0,0 +1,242 @@
+// Copyright 2026 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sumup-oss/go-pkgs/backoff"
+)
+
+var errRetryDeadlineExceeded = errors.New("retry deadline exceeded")
+
+// RetryOption configures RetryWithOptions.
+type RetryOption func(options *retryOptions)
+
+type retryOptions struct {
+	limitAttempts bool
+	maxAttempts   int
+	hasDeadline   bool
+	deadline      time.Duration
+	backoff       Backoff
+	retryIf       func(err error) bool
+	classifiers   []ErrorClassifier
+	onRetry       func(attempt int, err error, delay time.Duration)
+	budget        RetryBudget
+	reportCancel  bool
+}
+
+// WithMaxAttempts limits the number of times the task is run.
+//
+// When the task fails with retriable errors maxAttempts times, MaxRetryExceedError is returned.
+// If maxAttempts is less than 1, the task is not run at all.
+func WithMaxAttempts(maxAttempts int) RetryOption {
+	return func(options *retryOptions) {
+		options.limitAttempts = true
+		options.maxAttempts = maxAttempts
+	}
+}
+
+// WithDeadline limits the total time spent retrying.
+//
+// When the deadline is exceeded, the context passed to the task is canceled and DeadlineRetryError
+// is returned with the last non-nil error of the task.
+// If deadline is not positive, the deadline is exceeded right away.
+func WithDeadline(deadline time.Duration) RetryOption {
+	return func(options *retryOptions) {
+		options.hasDeadline = true
+		options.deadline = deadline
+	}
+}
+
+// WithBackoff sets the Backoff calculating the durations to wait between retries.
+//
+// When the backoff returns backoff.Stop, MaxRetryExceedError is returned.
+func WithBackoff(retryBackoff Backoff) RetryOption {
+	return func(options *retryOptions) {
+		options.backoff = retryBackoff
+	}
+}
+
+// WithInterval waits the same retryInterval between every retry.
+func WithInterval(retryInterval time.Duration) RetryOption {
+	return WithBackoff(intervalBackoff(retryInterval))
+}
+
+// WithRetryIf sets the predicate deciding if the task error can be retried.
+//
+// By default, the errors implementing the RetryableError interface are retried.
+func WithRetryIf(retryIf func(err error) bool) RetryOption {
+	return func(options *retryOptions) {
+		options.retryIf = retryIf
+	}
+}
+
+// WithClassifiers sets additional classifiers of retryable errors, e.g. TransientErrorClassifiers.
+//
+// The errors implementing the RetryableError interface are still retried.
+// The classifiers are ignored when WithRetryIf is used.
+func WithClassifiers(classifiers ...ErrorClassifier) RetryOption {
+	return func(options *retryOptions) {
+		options.classifiers = append(options.classifiers, classifiers...)
+	}
+}
+
+// WithOnRetry sets a hook called before waiting for every retry, with the number of the failed
+// attempt, its error and the delay before the next attempt.
+func WithOnRetry(onRetry func(attempt int, err error, delay time.Duration)) RetryOption {
+	return func(options *retryOptions) {
+		options.onRetry = onRetry
+	}
+}
+
+// WithRetryBudget withdraws every retry from the budget.
+//
+// When the budget is exhausted, RetryBudgetExceededError is returned.
+func WithRetryBudget(budget RetryBudget) RetryOption {
+	return func(options *retryOptions) {
+		options.budget = budget
+	}
+}
+
+// WithCanceledError makes RetryWithOptions return CanceledRetryError when the context is done
+// while retrying, instead of returning no error.
+func WithCanceledError() RetryOption {
+	return func(options *retryOptions) {
+		options.reportCancel = true
+	}
+}
+
+// RetryWithOptions retries a task until it returns no error or the returned error is non retriable.
+//
+// By default, an error is retriable when it implements the RetryableError interface and its
+// IsRetryable method returns true, the attempts are not limited and the delays between retries are
+// calculated by a backoff.Backoff with the default config.
+//
+// When the error suggests a delay by implementing RetryAfterError, the delay is used instead of
+// the backoff duration.
+//
+// NOTE: when the context is done, RetryWithOptions will not return an error, even if
+// the retryFunc had failed couple of times so far, unless WithCanceledError is used.
+func RetryWithOptions(retryFunc TaskFunc, opts ...RetryOption) TaskFunc {
+	return func(ctx context.Context) error {
+		options := &retryOptions{}
+
+		for _, opt := range opts {
+			opt(options)
+		}
+
+		if options.backoff == nil {
+			options.backoff = backoff.NewBackoff(&backoff.Config{})
+		}
+
+		var lastErr error
+
+		if !options.hasDeadline {
+			return options.retry(ctx, retryFunc, &lastErr)
+		}
+
+		retryCtx, cancel := context.WithTimeoutCause(ctx, options.deadline, errRetryDeadlineExceeded)
+		defer cancel()
+
+		err := options.retry(retryCtx, retryFunc, &lastErr)
+		if errors.Is(context.Cause(retryCtx), errRetryDeadlineExceeded) {
+			return NewDeadlineError(options.deadline, lastErr)
+		}
+
+		return err
+	}
+}
+
+// retry runs the retry loop.
+//
+// The last non-nil error of the task is stored in lastErr.
+func (o *retryOptions) retry(ctx context.Context, retryFunc TaskFunc, lastErr *error) error {
+	if o.limitAttempts && o.maxAttempts < 1 {
+		return NewMaxRetryError(o.maxAttempts, nil)
+	}
+
+	if o.budget != nil {
+		o.budget.Deposit()
+	}
+
+	for attempts := 1; ; attempts++ {
+		err := retryFunc(ctx)
+		if err == nil {
+			return nil
+		}
+
+		*lastErr = err
+		if !o.isRetryable(err) {
+			return err
+		}
+
+		if o.limitAttempts && attempts >= o.maxAttempts {
+			return NewMaxRetryError(o.maxAttempts, err)
+		}
+
+		if o.budget != nil && !o.budget.Withdraw() {
+			return NewRetryBudgetExceededError(err)
+		}
+
+		if ctx.Err() != nil {
+			return o.canceled(ctx, err)
+		}
+
+		delay := o.backoff.Next()
+		if delay == backoff.Stop {
+			return NewMaxRetryError(attempts, err)
+		}
+
+		retryAfter, ok := RetryAfter(err)
+		if ok {
+			delay = retryAfter
+		}
+
+		if o.onRetry != nil {
+			o.onRetry(attempts, err, delay)
+		}
+
+		if !sleepBackoff(ctx, o.backoff, delay) {
+			return o.canceled(ctx, err)
+		}
+	}
+}
+
+func (o *retryOptions) isRetryable(err error) bool {
+	if o.retryIf != nil {
+		return o.retryIf(err)
+	}
+
+	return IsRetryableErrorWith(err, o.classifiers...)
+}
+
+// canceled returns the error to return when the context is done while retrying.
+func (o *retryOptions) canceled(ctx context.Context, lastErr error) error {
+	if !o.reportCancel {
+		return nil
+	}
+
+	return NewCanceledRetryError(ctx.Err(), lastErr)
+}
+
+// intervalBackoff is a Backoff returning always the same duration.
+type intervalBackoff time.Duration
+
+func (b intervalBackoff) Next() time.Duration {
+	return time.Duration(b)
+}
@@ -0,0 +1,200 @@
+// Copyright 2026 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sumup-oss/go-pkgs/backoff"
+	"github.com/sumup-oss/go-pkgs/backoff/backofftest"
+	"github.com/sumup-oss/go-pkgs/task"
+)
+
+func TestRetryWithOptions(t *testing.T) {
+	t.Run("when a retry-if predicate is set, it retries the errors matching it", func(t *testing.T) {
+		t.Parallel()
+
+		errTemporary := errors.New("temporary")
+		cnt := 0
+		repeat := task.RetryWithOptions(
+			func(ctx context.Context) error {
+				cnt++
+				if cnt < 3 {
+					return errTemporary
+				}
+
+				return assert.AnError
+			},
+			task.WithInterval(1),
+			task.WithRetryIf(func(err error) bool {
+				return errors.Is(err, errTemporary)
+			}),
+		)
+
+		err := repeat(context.Background())
+
+		assert.Equal(t, assert.AnError, err)
+		assert.Equal(t, 3, cnt)
+	})
+
+	t.Run("when the task is retried, it calls the on-retry hook with the backoff delays", func(t *testing.T) {
+		t.Parallel()
+
+		type retry struct {
+			attempt int
+			err     error
+			delay   time.Duration
+		}
+
+		clock := backofftest.NewAutoAdvanceFakeClock(time.Now())
+		retryErr := task.NewRetryableError(errors.New("fooErr"))
+
+		var retries []retry
+
+		cnt := 0
+		repeat := task.RetryWithOptions(
+			func(ctx context.Context) error {
+				cnt++
+				if cnt > 3 {
+					return nil
+				}
+
+				return retryErr
+			},
+			task.WithBackoff(
+				backoff.NewBackoff(&backoff.Config{Base: time.Second, Jitter: backoff.NoJitter, Clock: clock}),
+			),
+			task.WithOnRetry(func(attempt int, err error, delay time.Duration) {
+				retries = append(retries, retry{attempt: attempt, err: err, delay: delay})
+			}),
+		)
+
+		err := repeat(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(
+			t,
+			[]retry{
+				{attempt: 1, err: retryErr, delay: time.Second},
+				{attempt: 2, err: retryErr, delay: 2 * time.Second},
+				{attempt: 3, err: retryErr, delay: 4 * time.Second},
+			},
+			retries,
+		)
+		assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}, clock.Sleeps())
+	})
+
+	t.Run("when max attempts is less than 1, it does not run the task", func(t *testing.T) {
+		t.Parallel()
+
+		cnt := 0
+		repeat := task.RetryWithOptions(
+			func(ctx context.Context) error {
+				cnt++
+
+				return nil
+			},
+			task.WithMaxAttempts(0),
+		)
+
+		err := repeat(context.Background())
+
+		require.IsType(t, (*task.MaxRetryExceedError)(nil), err)
+		assert.Equal(t, 0, cnt)
+	})
+
+	t.Run("when the retry budget is exhausted, it returns RetryBudgetExceededError", func(t *testing.T) {
+		t.Parallel()
+
+		budget := task.NewTokenBucketRetryBudget(&task.RetryBudgetConfig{MaxTokens: 2})
+		cnt := 0
+		repeat := task.RetryWithOptions(
+			func(ctx context.Context) error {
+				cnt++
+
+				return task.NewRetryableError(assert.AnError)
+			},
+			task.WithInterval(1),
+			task.WithRetryBudget(budget),
+		)
+
+		err := repeat(context.Background())
+
+		require.IsType(t, (*task.RetryBudgetExceededError)(nil), err)
+		assert.Equal(t, 3, cnt)
+	})
+
+	t.Run("when the deadline is exceeded, it returns DeadlineRetryError with the last error", func(t *testing.T) {
+		t.Parallel()
+
+		repeat := task.RetryWithOptions(
+			func(ctx context.Context) error {
+				return task.NewRetryableError(errors.New("fooErr"))
+			},
+			task.WithInterval(time.Hour),
+			task.WithDeadline(10*time.Millisecond),
+		)
+
+		err := repeat(context.Background())
+
+		require.IsType(t, (*task.DeadlineRetryError)(nil), err)
+		assert.EqualError(t, err, "deadline 10ms exceeded, last err: fooErr")
+	})
+	t.Run("when the context is done and canceled error is enabled, it returns CanceledRetryError", func(t *testing.T) {
+		t.Parallel()
+
+		retryErr := task.NewRetryableError(assert.AnError)
+		ctx, cancel := context.WithCancel(context.Background())
+		repeat := task.RetryWithOptions(
+			func(ctx context.Context) error {
+				cancel()
+
+				return retryErr
+			},
+			task.WithInterval(time.Hour),
+			task.WithCanceledError(),
+		)
+
+		err := repeat(ctx)
+
+		var canceledErr *task.CanceledRetryError
+		require.True(t, errors.As(err, &canceledErr))
+		assert.True(t, errors.Is(err, context.Canceled))
+		assert.True(t, errors.Is(err, retryErr))
+		assert.Equal(t, retryErr, canceledErr.Cause())
+	})
+
+	t.Run("when the context is done and canceled error is not enabled, it returns no error", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		repeat := task.RetryWithOptions(
+			func(ctx context.Context) error {
+				cancel()
+
+				return task.NewRetryableError(assert.AnError)
+			},
+			task.WithInterval(time.Hour),
+		)
+
+		assert.NoError(t, repeat(ctx))
+	})
+}
@@ -23,6 +23,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/sumup-oss/go-pkgs/backoff"
+	"github.com/sumup-oss/go-pkgs/backoff/backofftest"
 	"github.com/sumup-oss/go-pkgs/task"
 )
 
@@ -284,6 +286,23 @@ func TestRetryWithDeadline(t *testing.T) {
 		},
 	)
 
+	t.Run("when the deadline is zero, it stops retrying right away and returns DeadlineRetryError", func(t *testing.T) {
+		t.Parallel()
+
+		cnt := 0
+		repeat := task.RetryWithDeadline(0, time.Hour, func(ctx context.Context) error {
+			cnt++
+
+			return task.NewRetryableError(errors.New("fooErr"))
+		})
+
+		err := repeat(context.Background())
+
+		require.IsType(t, (*task.DeadlineRetryError)(nil), err)
+		assert.EqualError(t, err, "deadline 0s exceeded, last err: fooErr")
+		assert.Equal(t, 1, cnt)
+	})
+
 	t.Run(
 		"when the task func does not complete within the deadline, "+
 			"it cancels the task and returns DeadlineRetryError",
@@ -328,3 +347,62 @@ func TestRetryWithDeadline(t *testing.T) {
 		},
 	)
 }
+
+func TestRetryWithBackoff(t *testing.T) {
+	t.Run(
+		"when the backoff returns Stop, it stops retrying and returns MaxRetryExceedError",
+		func(t *testing.T) {
+			t.Parallel()
+
+			cnt := 0
+			repeat := task.RetryWithBackoff(
+				-1,
+				backoff.NewBackoff(&backoff.Config{Base: 1, Jitter: backoff.NoJitter, MaxAttempts: 2}),
+				func(ctx context.Context) error {
+					cnt++
+					return task.NewRetryableError(errors.New("fooErr"))
+				},
+			)
+
+			err := repeat(context.Background())
+
+			require.IsType(t, (*task.MaxRetryExceedError)(nil), err)
+			assert.EqualError(t, err, "max retry attempts 3 exceeded, last err: fooErr")
+			assert.Equal(t, 3, cnt)
+		},
+	)
+
+	t.Run(
+		"when the task func returns retriable errors, it sleeps for the backoff durations",
+		func(t *testing.T) {
+			t.Parallel()
+
+			clock := backofftest.NewAutoAdvanceFakeClock(time.Now())
+			cnt := 0
+			repeat := task.RetryWithBackoff(
+				10,
+				backoff.NewBackoff(&backoff.Config{Base: time.Second, Jitter: backoff.NoJitter, Clock: clock}),
+				func(ctx context.Context) error {
+					cnt++
+					if cnt > 6 {
+						return nil
+					}
+
+					return task.NewRetryableError(errors.New("fooErr"))
+				},
+			)
+
+			err := repeat(context.Background())
+
+			assert.NoError(t, err)
+			assert.Equal(t, 7, cnt)
+			assert.Equal(
+				t,
+				[]time.Duration{
+					time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second, 30 * time.Second,
+				},
+				clock.Sleeps(),
+			)
+		},
+	)
+}
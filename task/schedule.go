@@ -0,0 +1,280 @@
+// Copyright 2026 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/sumup-oss/go-pkgs/backoff"
+)
+
+// OverlapPolicy decides what happens when a scheduled run is due while the previous run is
+// still running.
+type OverlapPolicy int
+
+const (
+	// SkipIfRunning skips the due run.
+	SkipIfRunning OverlapPolicy = iota
+	// QueueIfRunning starts the due run as soon as the previous run finishes.
+	QueueIfRunning
+)
+
+// ScheduleOption configures Every and Cron.
+type ScheduleOption func(options *scheduleOptions)
+
+type scheduleOptions struct {
+	jitter          time.Duration
+	initialDelay    time.Duration
+	hasInitialDelay bool
+	overlapPolicy   OverlapPolicy
+	onError         func(err error)
+	clock           backoff.Clock
+}
+
+// WithJitter delays every run with a random duration in [0, jitter), so instances of the same
+// service do not run the task at the same time.
+func WithJitter(jitter time.Duration) ScheduleOption {
+	return func(options *scheduleOptions) {
+		options.jitter = jitter
+	}
+}
+
+// WithInitialDelay delays the first run of Every with initialDelay instead of the interval.
+// For Cron, the first run is the first matching time after the initialDelay.
+func WithInitialDelay(initialDelay time.Duration) ScheduleOption {
+	return func(options *scheduleOptions) {
+		options.initialDelay = initialDelay
+		options.hasInitialDelay = true
+	}
+}
+
+// WithOverlapPolicy sets the OverlapPolicy. By default, SkipIfRunning is used.
+func WithOverlapPolicy(policy OverlapPolicy) ScheduleOption {
+	return func(options *scheduleOptions) {
+		options.overlapPolicy = policy
+	}
+}
+
+// WithErrorHandler makes the schedule continue when a run fails, passing the error to onError.
+//
+// By default, the first failed run stops the schedule and its error is returned.
+func WithErrorHandler(onError func(err error)) ScheduleOption {
+	return func(options *scheduleOptions) {
+		options.onError = onError
+	}
+}
+
+// WithClock sets the clock used for scheduling, which allows faking the time in tests.
+func WithClock(clock backoff.Clock) ScheduleOption {
+	return func(options *scheduleOptions) {
+		options.clock = clock
+	}
+}
+
+// Every returns a task running fn every interval, until the context is done.
+//
+// The runs are scheduled at a fixed rate, not depending on how long fn runs. The runs missed,
+// e.g. because the process was suspended, are skipped.
+// When the context is done, the task waits for the current run to return, and returns no error.
+//
+// Every panics if interval is not positive.
+func Every(interval time.Duration, fn TaskFunc, opts ...ScheduleOption) TaskFunc {
+	if interval <= 0 {
+		panic("task: non-positive interval for Every")
+	}
+
+	return func(ctx context.Context) error {
+		options := newScheduleOptions(opts)
+
+		first := options.clock.Now().Add(interval)
+		if options.hasInitialDelay {
+			first = options.clock.Now().Add(options.initialDelay)
+		}
+
+		return runSchedule(ctx, first, func(prev time.Time) time.Time {
+			return prev.Add(interval)
+		}, fn, options)
+	}
+}
+
+// Cron returns a task running fn at the times matching the cron spec, until the context is done.
+//
+// See ParseCron for the spec format. The runs missed, e.g. because the process was suspended,
+// are skipped.
+// When the context is done, the task waits for the current run to return, and returns no error.
+func Cron(spec string, fn TaskFunc, opts ...ScheduleOption) (TaskFunc, error) {
+	schedule, err := ParseCron(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) error {
+		options := newScheduleOptions(opts)
+
+		first := schedule.Next(options.clock.Now().Add(options.initialDelay))
+		if first.IsZero() {
+			return fmt.Errorf("cron spec %q has no next run", spec)
+		}
+
+		return runSchedule(ctx, first, schedule.Next, fn, options)
+	}, nil
+}
+
+func newScheduleOptions(opts []ScheduleOption) *scheduleOptions {
+	options := &scheduleOptions{
+		clock: backoff.SystemClock{},
+	}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return options
+}
+
+// runSchedule runs fn at the first time and then at the times returned by next.
+func runSchedule(
+	ctx context.Context,
+	first time.Time,
+	next func(prev time.Time) time.Time,
+	fn TaskFunc,
+	options *scheduleOptions,
+) error {
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	runner := &scheduleRunner{
+		fn:      fn,
+		options: options,
+		errCh:   make(chan error, 1),
+		cancel:  cancel,
+	}
+
+	for due := first; !due.IsZero(); {
+		delay := due.Sub(options.clock.Now())
+		if options.jitter > 0 {
+			delay += time.Duration(rand.Int64N(int64(options.jitter))) //nolint:gosec
+		}
+
+		timer := options.clock.NewTimer(delay)
+
+		select {
+		case <-runCtx.Done():
+			timer.Stop()
+
+			return runner.err()
+		case <-timer.C():
+		}
+
+		runner.trigger(runCtx)
+
+		now := options.clock.Now()
+		for !due.IsZero() && !due.After(now) {
+			due = next(due)
+		}
+	}
+
+	return runner.err()
+}
+
+// scheduleRunner runs fn in a goroutine, applying the overlap policy.
+type scheduleRunner struct {
+	fn      TaskFunc
+	options *scheduleOptions
+	errCh   chan error
+	cancel  context.CancelFunc
+
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	running bool
+	queued  int
+}
+
+func (r *scheduleRunner) trigger(ctx context.Context) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.running {
+		if r.options.overlapPolicy == QueueIfRunning {
+			r.queued++
+		}
+
+		return
+	}
+
+	r.running = true
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+
+		r.run(ctx)
+	}()
+}
+
+// run runs fn, and then the queued runs one after another.
+func (r *scheduleRunner) run(ctx context.Context) {
+	for {
+		err := r.fn(ctx)
+		// the error of a run stopped by the cancellation, typically ctx.Err(), is not a failure
+		if err != nil && ctx.Err() == nil {
+			r.failed(err)
+		}
+
+		r.mu.Lock()
+
+		if r.queued == 0 || ctx.Err() != nil {
+			r.running = false
+			r.queued = 0
+			r.mu.Unlock()
+
+			return
+		}
+
+		r.queued--
+		r.mu.Unlock()
+	}
+}
+
+func (r *scheduleRunner) failed(err error) {
+	if r.options.onError != nil {
+		r.options.onError(err)
+
+		return
+	}
+
+	select {
+	case r.errCh <- err:
+	default:
+	}
+
+	r.cancel()
+}
+
+// err returns the error of the failed run, after waiting for the current run to return.
+func (r *scheduleRunner) err() error {
+	r.wg.Wait()
+
+	select {
+	case err := <-r.errCh:
+		return err
+	default:
+		return nil
+	}
+}
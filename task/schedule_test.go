@@ -0,0 +1,294 @@
+// Copyright 2026 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sumup-oss/go-pkgs/backoff/backofftest"
+	"github.com/sumup-oss/go-pkgs/task"
+)
+
+func TestEvery(t *testing.T) {
+	t.Run("it runs the task every interval after the initial delay", func(t *testing.T) {
+		t.Parallel()
+
+		clock := backofftest.NewAutoAdvanceFakeClock(time.Now())
+		ctx, cancel := context.WithCancel(context.Background())
+
+		var runs int32
+		every := task.Every(
+			time.Minute,
+			func(ctx context.Context) error {
+				if atomic.AddInt32(&runs, 1) == 3 {
+					cancel()
+				}
+
+				return nil
+			},
+			task.WithInitialDelay(time.Second),
+			task.WithOverlapPolicy(task.QueueIfRunning),
+			task.WithClock(clock),
+		)
+
+		err := every(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, []time.Duration{time.Second, time.Minute}, clock.Sleeps()[:2])
+	})
+
+	t.Run("when jitter is set, it delays the runs with up to the jitter", func(t *testing.T) {
+		t.Parallel()
+
+		clock := backofftest.NewAutoAdvanceFakeClock(time.Now())
+		ctx, cancel := context.WithCancel(context.Background())
+
+		every := task.Every(
+			time.Minute,
+			func(ctx context.Context) error {
+				cancel()
+
+				return nil
+			},
+			task.WithJitter(time.Second),
+			task.WithClock(clock),
+		)
+
+		err := every(ctx)
+
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, clock.Sleeps()[0], time.Minute)
+		assert.Less(t, clock.Sleeps()[0], time.Minute+time.Second)
+	})
+
+	t.Run("when the previous run is still running and the policy is skip, it skips the run", func(t *testing.T) {
+		t.Parallel()
+
+		clock := backofftest.NewFakeClock(time.Now())
+		ctx, cancel := context.WithCancel(context.Background())
+		started := make(chan struct{}, 10)
+		release := make(chan struct{})
+
+		every := task.Every(
+			time.Minute,
+			func(ctx context.Context) error {
+				started <- struct{}{}
+				<-release
+
+				return nil
+			},
+			task.WithClock(clock),
+		)
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- every(ctx)
+		}()
+
+		clock.WaitForTimers(1)
+		clock.Advance(time.Minute)
+		<-started
+
+		clock.WaitForTimers(2)
+		clock.Advance(time.Minute)
+		clock.WaitForTimers(3)
+		close(release)
+
+		cancel()
+		require.NoError(t, <-errCh)
+		assert.Len(t, started, 0)
+	})
+
+	t.Run("when the previous run is still running and the policy is queue, it runs after it", func(t *testing.T) {
+		t.Parallel()
+
+		clock := backofftest.NewFakeClock(time.Now())
+		ctx, cancel := context.WithCancel(context.Background())
+		started := make(chan struct{}, 10)
+		release := make(chan struct{})
+
+		every := task.Every(
+			time.Minute,
+			func(ctx context.Context) error {
+				started <- struct{}{}
+				<-release
+
+				return nil
+			},
+			task.WithOverlapPolicy(task.QueueIfRunning),
+			task.WithClock(clock),
+		)
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- every(ctx)
+		}()
+
+		clock.WaitForTimers(1)
+		clock.Advance(time.Minute)
+		<-started
+
+		clock.WaitForTimers(2)
+		clock.Advance(time.Minute)
+		clock.WaitForTimers(3)
+		close(release)
+		<-started
+
+		cancel()
+		require.NoError(t, <-errCh)
+	})
+
+	t.Run("when a run fails, it stops and returns the error", func(t *testing.T) {
+		t.Parallel()
+
+		clock := backofftest.NewAutoAdvanceFakeClock(time.Now())
+
+		every := task.Every(
+			time.Minute,
+			func(ctx context.Context) error {
+				return assert.AnError
+			},
+			task.WithClock(clock),
+		)
+
+		assert.Equal(t, assert.AnError, every(context.Background()))
+	})
+
+	t.Run("when a run fails and there is an error handler, it continues", func(t *testing.T) {
+		t.Parallel()
+
+		clock := backofftest.NewAutoAdvanceFakeClock(time.Now())
+		ctx, cancel := context.WithCancel(context.Background())
+
+		var runs, errs int32
+		every := task.Every(
+			time.Minute,
+			func(ctx context.Context) error {
+				if atomic.AddInt32(&runs, 1) == 4 {
+					cancel()
+				}
+
+				return assert.AnError
+			},
+			task.WithOverlapPolicy(task.QueueIfRunning),
+			task.WithErrorHandler(func(err error) {
+				atomic.AddInt32(&errs, 1)
+			}),
+			task.WithClock(clock),
+		)
+
+		err := every(ctx)
+
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, atomic.LoadInt32(&errs), int32(3))
+	})
+
+	t.Run("when the context is done, it waits for the current run to return", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		var returned int32
+		every := task.Every(
+			time.Millisecond,
+			func(ctx context.Context) error {
+				cancel()
+				<-ctx.Done()
+				time.Sleep(10 * time.Millisecond)
+				atomic.StoreInt32(&returned, 1)
+
+				return nil
+			},
+		)
+
+		err := every(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&returned))
+	})
+
+	t.Run("when the run returns the context error after the cancellation, it returns no error", func(t *testing.T) {
+		t.Parallel()
+
+		group := task.NewGroup()
+		started := make(chan struct{})
+
+		var once sync.Once
+
+		group.Go(task.Every(
+			time.Millisecond,
+			func(ctx context.Context) error {
+				once.Do(func() {
+					close(started)
+				})
+				<-ctx.Done()
+
+				return ctx.Err()
+			},
+		))
+
+		<-started
+		group.Cancel()
+
+		assert.NoError(t, group.Wait(context.Background()))
+	})
+}
+
+func TestCron(t *testing.T) {
+	t.Run("when the spec is invalid, it returns error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := task.Cron("foo", func(ctx context.Context) error {
+			return nil
+		})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("it runs the task at the matching times", func(t *testing.T) {
+		t.Parallel()
+
+		clock := backofftest.NewAutoAdvanceFakeClock(time.Date(2026, 1, 2, 10, 0, 30, 0, time.UTC))
+		ctx, cancel := context.WithCancel(context.Background())
+
+		var runs []time.Time
+		cron, err := task.Cron(
+			"*/15 * * * *",
+			func(ctx context.Context) error {
+				runs = append(runs, clock.Now())
+				if len(runs) == 2 {
+					cancel()
+				}
+
+				return nil
+			},
+			task.WithOverlapPolicy(task.QueueIfRunning),
+			task.WithClock(clock),
+		)
+		require.NoError(t, err)
+
+		err = cron(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, []time.Duration{14*time.Minute + 30*time.Second, 15 * time.Minute}, clock.Sleeps()[:2])
+	})
+}
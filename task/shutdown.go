@@ -0,0 +1,194 @@
+// Copyright 2026 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ShutdownPhaseError is an error of a shutdown phase that did not finish successfully.
+type ShutdownPhaseError struct {
+	// Phase is the phase name.
+	Phase string
+	// Err is the error returned by the phase, or context.DeadlineExceeded if the phase timed out.
+	Err error
+}
+
+// Error returns the error message.
+func (err *ShutdownPhaseError) Error() string {
+	return fmt.Sprintf("shutdown phase %q: %v", err.Phase, err.Err)
+}
+
+// Unwrap returns the phase error.
+func (err *ShutdownPhaseError) Unwrap() error {
+	return err.Err
+}
+
+// ShutdownError is returned by ShutdownCoordinator.Run when some of the phases did not finish
+// successfully.
+type ShutdownError struct {
+	// Errors are the errors of the failed phases in the order of the phases.
+	Errors []*ShutdownPhaseError
+}
+
+// Error returns the error message.
+func (err *ShutdownError) Error() string {
+	messages := make([]string, 0, len(err.Errors))
+	for _, phaseErr := range err.Errors {
+		messages = append(messages, phaseErr.Error())
+	}
+
+	return fmt.Sprintf("%d shutdown phases failed: %s", len(err.Errors), strings.Join(messages, "; "))
+}
+
+// Unwrap returns the phase errors.
+func (err *ShutdownError) Unwrap() []error {
+	errs := make([]error, 0, len(err.Errors))
+	for _, phaseErr := range err.Errors {
+		errs = append(errs, phaseErr)
+	}
+
+	return errs
+}
+
+type shutdownPhase struct {
+	name    string
+	timeout time.Duration
+	fn      TaskFunc
+}
+
+// ShutdownCoordinator waits for a shutdown signal and runs the shutdown phases in order.
+//
+// Every phase runs with its own timeout. A phase that fails or times out does not prevent the next
+// phases from running, and is reported in the ShutdownError returned by Run.
+//
+// Example:
+//
+//	group := task.NewGroup()
+//	group.Go(consumer.Run)
+//
+//	coordinator := task.NewShutdownCoordinator()
+//	coordinator.AddPhase("http", 5*time.Second, server.Shutdown)
+//	coordinator.AddPhase("consumers", 30*time.Second, func(ctx context.Context) error {
+//		group.Cancel()
+//
+//		return group.Wait(ctx)
+//	})
+//	coordinator.AddPhase("logger", time.Second, func(ctx context.Context) error {
+//		return log.Sync()
+//	})
+//
+//	err := coordinator.Run(context.Background())
+type ShutdownCoordinator struct {
+	signals []os.Signal
+	phases  []*shutdownPhase
+
+	shutdownOnce sync.Once
+	shutdownCh   chan struct{}
+}
+
+// NewShutdownCoordinator creates ShutdownCoordinator instance, listening for the provided signals.
+//
+// If no signals are provided, SIGINT and SIGTERM are used.
+func NewShutdownCoordinator(signals ...os.Signal) *ShutdownCoordinator {
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+
+	return &ShutdownCoordinator{
+		signals:    signals,
+		shutdownCh: make(chan struct{}),
+	}
+}
+
+// AddPhase adds a shutdown phase. The phases run in the order they were added.
+//
+// The context passed to fn is canceled when the timeout is exceeded.
+// AddPhase must be called before Run.
+func (c *ShutdownCoordinator) AddPhase(name string, timeout time.Duration, fn TaskFunc) {
+	c.phases = append(c.phases, &shutdownPhase{
+		name:    name,
+		timeout: timeout,
+		fn:      fn,
+	})
+}
+
+// Shutdown starts the shutdown without waiting for a signal.
+func (c *ShutdownCoordinator) Shutdown() {
+	c.shutdownOnce.Do(func() {
+		close(c.shutdownCh)
+	})
+}
+
+// Run waits until one of the signals is received, Shutdown is called, or the context is done,
+// and then runs the shutdown phases.
+//
+// The phases get a context that keeps the values of ctx, but is not canceled with it.
+// It returns ShutdownError if any of the phases failed or timed out.
+func (c *ShutdownCoordinator) Run(ctx context.Context) error {
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, c.signals...)
+
+	select {
+	case <-signalCh:
+	case <-c.shutdownCh:
+	case <-ctx.Done():
+	}
+
+	signal.Stop(signalCh)
+
+	phaseCtx := context.WithoutCancel(ctx)
+
+	var phaseErrs []*ShutdownPhaseError
+
+	for _, phase := range c.phases {
+		err := phase.run(phaseCtx)
+		if err != nil {
+			phaseErrs = append(phaseErrs, &ShutdownPhaseError{Phase: phase.name, Err: err})
+		}
+	}
+
+	if len(phaseErrs) > 0 {
+		return &ShutdownError{Errors: phaseErrs}
+	}
+
+	return nil
+}
+
+// run runs the phase and waits at most for the phase timeout, even if fn does not respect
+// the context cancellation.
+func (p *shutdownPhase) run(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- p.fn(ctx)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
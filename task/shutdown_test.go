@@ -0,0 +1,151 @@
+// Copyright 2026 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sumup-oss/go-pkgs/task"
+)
+
+func TestShutdownCoordinator(t *testing.T) {
+	t.Run("when Shutdown is called, it runs the phases in order", func(t *testing.T) {
+		t.Parallel()
+
+		var phases []string
+
+		coordinator := task.NewShutdownCoordinator()
+		for _, name := range []string{"http", "consumers", "logger"} {
+			name := name
+			coordinator.AddPhase(name, time.Second, func(ctx context.Context) error {
+				phases = append(phases, name)
+
+				return nil
+			})
+		}
+
+		coordinator.Shutdown()
+		err := coordinator.Run(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"http", "consumers", "logger"}, phases)
+	})
+
+	t.Run("when the context is done, it runs the phases with a not canceled context", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var phaseCtxErr error
+
+		coordinator := task.NewShutdownCoordinator()
+		coordinator.AddPhase("http", time.Second, func(ctx context.Context) error {
+			phaseCtxErr = ctx.Err()
+
+			return nil
+		})
+
+		err := coordinator.Run(ctx)
+
+		require.NoError(t, err)
+		assert.NoError(t, phaseCtxErr)
+	})
+
+	t.Run("when a signal is received, it runs the phases", func(t *testing.T) {
+		t.Parallel()
+
+		// Keeps the signal from terminating the test process before Run starts listening.
+		ignoreCh := make(chan os.Signal, 1)
+		signal.Notify(ignoreCh, syscall.SIGUSR1)
+		defer signal.Stop(ignoreCh)
+
+		done := make(chan struct{})
+
+		coordinator := task.NewShutdownCoordinator(syscall.SIGUSR1)
+		coordinator.AddPhase("http", time.Second, func(ctx context.Context) error {
+			close(done)
+
+			return nil
+		})
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- coordinator.Run(context.Background())
+		}()
+
+		require.Eventually(t, func() bool {
+			_ = syscall.Kill(os.Getpid(), syscall.SIGUSR1)
+
+			select {
+			case <-done:
+				return true
+			default:
+				return false
+			}
+		}, time.Second, 10*time.Millisecond)
+
+		assert.NoError(t, <-errCh)
+	})
+
+	t.Run("when phases fail or time out, it runs the next phases and reports the failed ones", func(t *testing.T) {
+		t.Parallel()
+
+		var loggerRan bool
+
+		coordinator := task.NewShutdownCoordinator()
+		coordinator.AddPhase("http", 10*time.Millisecond, func(ctx context.Context) error {
+			// Ignores the context cancellation.
+			time.Sleep(time.Second)
+
+			return nil
+		})
+		coordinator.AddPhase("consumers", time.Second, func(ctx context.Context) error {
+			return assert.AnError
+		})
+		coordinator.AddPhase("logger", time.Second, func(ctx context.Context) error {
+			loggerRan = true
+
+			return nil
+		})
+
+		coordinator.Shutdown()
+		err := coordinator.Run(context.Background())
+
+		var shutdownErr *task.ShutdownError
+		require.True(t, errors.As(err, &shutdownErr))
+		require.Len(t, shutdownErr.Errors, 2)
+		assert.Equal(t, "http", shutdownErr.Errors[0].Phase)
+		assert.True(t, errors.Is(shutdownErr.Errors[0], context.DeadlineExceeded))
+		assert.Equal(t, "consumers", shutdownErr.Errors[1].Phase)
+		assert.True(t, errors.Is(err, assert.AnError))
+		assert.True(t, loggerRan)
+		assert.EqualError(
+			t,
+			err,
+			`2 shutdown phases failed: shutdown phase "http": context deadline exceeded; `+
+				`shutdown phase "consumers": `+assert.AnError.Error(),
+		)
+	})
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"context"
+	"sync"
+)
+
+// singleflightCall is a run of the task shared by concurrent callers.
+type singleflightCall struct {
+	done    chan struct{}
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// Singleflight is a TaskFuncDecorator that collapses concurrent calls of the task into one run.
+//
+// A call made while the task is running waits for the running task and returns its error,
+// instead of running the task again.
+//
+// The shared run gets the context values of the call that started it, and it is canceled only
+// when the contexts of all the waiting calls are done. A call whose context is done returns
+// the context error without waiting.
+//
+// The task runs in its own goroutine, so put Recover after Singleflight to recover its panics.
+func Singleflight(fn TaskFunc) TaskFunc {
+	var mu sync.Mutex
+
+	var call *singleflightCall
+
+	return func(ctx context.Context) error {
+		mu.Lock()
+
+		current := call
+		if current == nil {
+			runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+			current = &singleflightCall{
+				done:   make(chan struct{}),
+				cancel: cancel,
+			}
+			call = current
+
+			go func() {
+				defer cancel()
+
+				current.err = fn(runCtx)
+
+				mu.Lock()
+				// the call may be already replaced, when it was canceled
+				if call == current {
+					call = nil
+				}
+				mu.Unlock()
+
+				close(current.done)
+			}()
+		}
+
+		current.waiters++
+		mu.Unlock()
+
+		select {
+		case <-current.done:
+			return current.err
+		case <-ctx.Done():
+			mu.Lock()
+			current.waiters--
+			if current.waiters == 0 {
+				current.cancel()
+
+				// the next call must not join the canceled run
+				if call == current {
+					call = nil
+				}
+			}
+			mu.Unlock()
+
+			return ctx.Err()
+		}
+	}
+}
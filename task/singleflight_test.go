@@ -0,0 +1,156 @@
+// Copyright 2026 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sumup-oss/go-pkgs/task"
+)
+
+// joinNotifyingContext notifies when Done is called, which happens after the call joined the run.
+type joinNotifyingContext struct {
+	context.Context
+	once   sync.Once
+	joined chan struct{}
+}
+
+func newJoinNotifyingContext() *joinNotifyingContext {
+	return &joinNotifyingContext{
+		Context: context.Background(),
+		joined:  make(chan struct{}),
+	}
+}
+
+func (ctx *joinNotifyingContext) Done() <-chan struct{} {
+	ctx.once.Do(func() {
+		close(ctx.joined)
+	})
+
+	return ctx.Context.Done()
+}
+
+func TestSingleflight(t *testing.T) {
+	t.Run("when the task is called concurrently, it runs it once and shares the error", func(t *testing.T) {
+		t.Parallel()
+
+		var runs int32
+
+		started := make(chan struct{}, 1)
+		release := make(chan struct{})
+		fn := task.Singleflight(func(ctx context.Context) error {
+			atomic.AddInt32(&runs, 1)
+			started <- struct{}{}
+			<-release
+
+			return assert.AnError
+		})
+
+		errs := make(chan error, 3)
+
+		go func() {
+			errs <- fn(context.Background())
+		}()
+		<-started
+
+		for i := 0; i < 2; i++ {
+			ctx := newJoinNotifyingContext()
+
+			go func() {
+				errs <- fn(ctx)
+			}()
+
+			<-ctx.joined
+		}
+
+		close(release)
+
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, assert.AnError, <-errs)
+		}
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&runs))
+	})
+
+	t.Run("when the previous run finished, it runs the task again", func(t *testing.T) {
+		t.Parallel()
+
+		var runs int32
+		fn := task.Singleflight(func(ctx context.Context) error {
+			atomic.AddInt32(&runs, 1)
+
+			return nil
+		})
+
+		assert.NoError(t, fn(context.Background()))
+		assert.NoError(t, fn(context.Background()))
+		assert.Equal(t, int32(2), atomic.LoadInt32(&runs))
+	})
+
+	t.Run("when the contexts of all the callers are done, it cancels the run", func(t *testing.T) {
+		t.Parallel()
+
+		runCanceled := make(chan struct{})
+		fn := task.Singleflight(func(ctx context.Context) error {
+			<-ctx.Done()
+			close(runCanceled)
+
+			return ctx.Err()
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		assert.Equal(t, context.Canceled, fn(ctx))
+		<-runCanceled
+	})
+
+	t.Run("when the canceled run is still returning, the next call runs the task again", func(t *testing.T) {
+		t.Parallel()
+
+		type firstCallKey struct{}
+
+		release := make(chan struct{})
+		defer close(release)
+
+		fn := task.Singleflight(func(ctx context.Context) error {
+			if ctx.Value(firstCallKey{}) == nil {
+				return nil
+			}
+
+			<-ctx.Done()
+			<-release
+
+			return ctx.Err()
+		})
+
+		ctx, cancel := context.WithCancel(context.WithValue(context.Background(), firstCallKey{}, true))
+		cancel()
+
+		assert.Equal(t, context.Canceled, fn(ctx))
+
+		// joining the canceled run would block until the timeout
+		nextCtx, nextCancel := context.WithTimeout(context.Background(), time.Second)
+		defer nextCancel()
+
+		assert.NoError(t, fn(nextCtx))
+	})
+}
@@ -0,0 +1,383 @@
+// Copyright 2026 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sumup-oss/go-pkgs/backoff"
+)
+
+// RestartPolicy specifies when a supervised task is restarted.
+type RestartPolicy int
+
+const (
+	// Permanent tasks are always restarted.
+	Permanent RestartPolicy = iota
+	// Transient tasks are restarted only when they return an error.
+	Transient
+	// Temporary tasks are never restarted.
+	Temporary
+)
+
+// RestartStrategy specifies which tasks are restarted when a supervised task has to be restarted.
+type RestartStrategy int
+
+const (
+	// OneForOne restarts only the task that stopped.
+	OneForOne RestartStrategy = iota
+	// OneForAll stops all the other tasks and restarts them together with the task that stopped.
+	// Temporary tasks stopped this way are not restarted.
+	OneForAll
+)
+
+// SupervisorConfig is used for the supervisor constructor.
+type SupervisorConfig struct {
+	// Strategy is the restart strategy, OneForOne by default.
+	Strategy RestartStrategy
+	// BackoffConfig is used for the delays before the restarts. When the backoff returns
+	// backoff.Stop, the supervisor stops with MaxRestartsExceededError.
+	// Defaults to backoff.DefaultConfig.
+	BackoffConfig *backoff.Config
+	// MaxRestarts is the maximum number of restarts within MaxRestartsPeriod, after which
+	// the supervisor stops all the tasks and returns MaxRestartsExceededError.
+	// Zero means no limit.
+	MaxRestarts int
+	// MaxRestartsPeriod is the period for MaxRestarts. Zero means that the consecutive restarts are
+	// counted instead, until a task runs for HealthyAfter.
+	MaxRestartsPeriod time.Duration
+	// HealthyAfter is how long a task needs to run to be considered healthy. When a healthy task
+	// stops, the backoff is reset and the restart delays start from BackoffConfig.Base again.
+	// Zero means that the tasks are never considered healthy.
+	HealthyAfter time.Duration
+	// RetryBudget is consulted before every restart, when it is exhausted the supervisor stops with
+	// RetryBudgetExceededError. A deposit is made on Run and whenever a task stops after running for
+	// HealthyAfter. It is optional.
+	RetryBudget RetryBudget
+	// OnRestart is called before every restart, e.g. for logging. It is optional.
+	OnRestart func(name string, err error)
+}
+
+// MaxRestartsExceededError is returned when the supervisor restart intensity is exceeded.
+type MaxRestartsExceededError struct {
+	name    string
+	lastErr error
+}
+
+// NewMaxRestartsExceededError creates MaxRestartsExceededError instance.
+func NewMaxRestartsExceededError(name string, lastErr error) error {
+	return &MaxRestartsExceededError{
+		name:    name,
+		lastErr: lastErr,
+	}
+}
+
+// Error returns the error message.
+func (err *MaxRestartsExceededError) Error() string {
+	return fmt.Sprintf("max restarts exceeded by task %q, last err: %v", err.name, err.lastErr)
+}
+
+// Cause returns the last error of the task that exceeded the max restarts.
+func (err *MaxRestartsExceededError) Cause() error {
+	return err.lastErr
+}
+
+// IsRetryable returns false, because the supervisor already gave up restarting the task.
+func (err *MaxRestartsExceededError) IsRetryable() bool {
+	return false
+}
+
+// Supervisor runs tasks and restarts them according to their RestartPolicy and the supervisor
+// RestartStrategy.
+//
+// Example:
+//
+//	supervisor := task.NewSupervisor(&task.SupervisorConfig{
+//		BackoffConfig:     &backoff.Config{Base: time.Second, Max: time.Minute},
+//		MaxRestarts:       5,
+//		MaxRestartsPeriod: time.Minute,
+//	})
+//
+//	supervisor.Add("consumer", task.Permanent, consumer.Run)
+//	supervisor.Add("migration", task.Transient, migrate)
+//
+//	group.Go(supervisor.Run)
+type Supervisor struct {
+	config *SupervisorConfig
+	clock  backoff.Clock
+	tasks  []*supervisedTask
+
+	// mu protects the restarts property
+	mu       sync.Mutex
+	restarts []time.Time
+}
+
+type supervisedTask struct {
+	index  int
+	name   string
+	policy RestartPolicy
+	fn     TaskFunc
+}
+
+// restartState tracks the restarts of a single task in OneForOne, or all the tasks in OneForAll.
+type restartState struct {
+	backoff     *backoff.Backoff
+	consecutive int
+}
+
+// NewSupervisor creates Supervisor instance.
+func NewSupervisor(config *SupervisorConfig) *Supervisor {
+	if config.BackoffConfig == nil {
+		config.BackoffConfig = backoff.DefaultConfig
+	}
+
+	var clock backoff.Clock = backoff.SystemClock{}
+	if config.BackoffConfig.Clock != nil {
+		clock = config.BackoffConfig.Clock
+	}
+
+	return &Supervisor{
+		config: config,
+		clock:  clock,
+	}
+}
+
+// Add adds a task to the supervisor. It must be called before Run.
+func (s *Supervisor) Add(name string, policy RestartPolicy, fn TaskFunc) {
+	s.tasks = append(s.tasks, &supervisedTask{
+		index:  len(s.tasks),
+		name:   name,
+		policy: policy,
+		fn:     fn,
+	})
+}
+
+// Run runs the tasks until the context is canceled, all the tasks stop without the need of
+// a restart, or the restart intensity is exceeded.
+//
+// It returns an error only when the supervisor gives up restarting the tasks, i.e.
+// MaxRestartsExceededError or RetryBudgetExceededError.
+func (s *Supervisor) Run(ctx context.Context) error {
+	if s.config.RetryBudget != nil {
+		s.config.RetryBudget.Deposit()
+	}
+
+	if s.config.Strategy == OneForAll {
+		return s.runOneForAll(ctx, s.newRestartState())
+	}
+
+	// The restart states are created upfront, since creating a backoff fills in the default values
+	// of the shared backoff config.
+	states := make([]*restartState, len(s.tasks))
+	for i := range s.tasks {
+		states[i] = s.newRestartState()
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := make(chan error, len(s.tasks))
+
+	for i, t := range s.tasks {
+		go func(t *supervisedTask, state *restartState) {
+			errs <- s.runOneForOne(runCtx, t, state)
+		}(t, states[i])
+	}
+
+	var firstErr error
+
+	for range s.tasks {
+		err := <-errs
+		if err != nil && firstErr == nil {
+			firstErr = err
+
+			cancel()
+		}
+	}
+
+	return firstErr
+}
+
+func (s *Supervisor) runOneForOne(ctx context.Context, t *supervisedTask, state *restartState) error {
+	for {
+		startTime := s.clock.Now()
+
+		err := t.fn(ctx)
+		if ctx.Err() != nil || !t.needsRestart(err) {
+			return nil
+		}
+
+		err = s.restart(ctx, state, t.name, err, startTime)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+type taskResult struct {
+	task *supervisedTask
+	err  error
+}
+
+func (s *Supervisor) runOneForAll(ctx context.Context, state *restartState) error {
+	tasks := s.tasks
+
+	for len(tasks) > 0 {
+		startTime := s.clock.Now()
+		roundCtx, cancel := context.WithCancel(ctx)
+		results := make(chan taskResult, len(tasks))
+
+		for _, t := range tasks {
+			go func(t *supervisedTask) {
+				results <- taskResult{task: t, err: t.fn(roundCtx)}
+			}(t)
+		}
+
+		var failed *taskResult
+
+		restartTasks := make([]*supervisedTask, 0, len(tasks))
+
+		for range tasks {
+			result := <-results
+
+			switch {
+			case ctx.Err() != nil:
+			case failed != nil:
+				// The task was stopped by the supervisor.
+				if result.task.policy != Temporary {
+					restartTasks = append(restartTasks, result.task)
+				}
+			case result.task.needsRestart(result.err):
+				failed = &result
+				restartTasks = append(restartTasks, result.task)
+
+				cancel()
+			}
+		}
+
+		cancel()
+
+		if ctx.Err() != nil || failed == nil {
+			return nil
+		}
+
+		err := s.restart(ctx, state, failed.task.name, failed.err, startTime)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		sort.Slice(restartTasks, func(i, j int) bool {
+			return restartTasks[i].index < restartTasks[j].index
+		})
+
+		tasks = restartTasks
+	}
+
+	return nil
+}
+
+// restart checks the restart intensity and waits for the backoff delay.
+func (s *Supervisor) restart(
+	ctx context.Context,
+	state *restartState,
+	name string,
+	err error,
+	startTime time.Time,
+) error {
+	if s.config.HealthyAfter > 0 && s.clock.Now().Sub(startTime) >= s.config.HealthyAfter {
+		state.backoff.Reset()
+		state.consecutive = 0
+
+		// A healthy run counts as a new call, otherwise the rare restarts of a long running task
+		// would exhaust the budget eventually.
+		if s.config.RetryBudget != nil {
+			s.config.RetryBudget.Deposit()
+		}
+	}
+
+	if !s.allowRestart(state) {
+		return NewMaxRestartsExceededError(name, err)
+	}
+
+	if s.config.RetryBudget != nil && !s.config.RetryBudget.Withdraw() {
+		return NewRetryBudgetExceededError(err)
+	}
+
+	if s.config.OnRestart != nil {
+		s.config.OnRestart(name, err)
+	}
+
+	waitErr := state.backoff.Wait(ctx)
+	if waitErr == backoff.ErrStopped {
+		return NewMaxRestartsExceededError(name, err)
+	}
+
+	return waitErr
+}
+
+func (s *Supervisor) allowRestart(state *restartState) bool {
+	if s.config.MaxRestartsPeriod == 0 {
+		state.consecutive++
+
+		return s.config.MaxRestarts == 0 || state.consecutive <= s.config.MaxRestarts
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+
+	recent := s.restarts[:0]
+	for _, restartTime := range s.restarts {
+		if now.Sub(restartTime) < s.config.MaxRestartsPeriod {
+			recent = append(recent, restartTime)
+		}
+	}
+
+	s.restarts = append(recent, now)
+
+	return s.config.MaxRestarts == 0 || len(s.restarts) <= s.config.MaxRestarts
+}
+
+func (s *Supervisor) newRestartState() *restartState {
+	return &restartState{
+		backoff: backoff.NewBackoff(s.config.BackoffConfig),
+	}
+}
+
+func (t *supervisedTask) needsRestart(err error) bool {
+	switch t.policy {
+	case Permanent:
+		return true
+	case Transient:
+		return err != nil
+	case Temporary:
+		return false
+	default:
+		return false
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright 2026 SumUp Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sumup-oss/go-pkgs/backoff"
+	"github.com/sumup-oss/go-pkgs/backoff/backofftest"
+	"github.com/sumup-oss/go-pkgs/task"
+)
+
+func newTestSupervisorConfig(clock *backofftest.FakeClock) *task.SupervisorConfig {
+	return &task.SupervisorConfig{
+		BackoffConfig: &backoff.Config{
+			Base:   time.Second,
+			Max:    time.Minute,
+			Jitter: backoff.NoJitter,
+			Clock:  clock,
+		},
+	}
+}
+
+func TestSupervisor_Run(t *testing.T) {
+	t.Run("it restarts a transient task until it returns no error", func(t *testing.T) {
+		t.Parallel()
+
+		clock := backofftest.NewAutoAdvanceFakeClock(time.Now())
+		supervisor := task.NewSupervisor(newTestSupervisorConfig(clock))
+
+		cnt := 0
+		supervisor.Add("foo", task.Transient, func(ctx context.Context) error {
+			cnt++
+			if cnt < 3 {
+				return errors.New("fooErr")
+			}
+
+			return nil
+		})
+
+		err := supervisor.Run(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, cnt)
+		assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, clock.Sleeps())
+	})
+
+	t.Run("it does not restart a temporary task", func(t *testing.T) {
+		t.Parallel()
+
+		supervisor := task.NewSupervisor(newTestSupervisorConfig(backofftest.NewAutoAdvanceFakeClock(time.Now())))
+
+		cnt := 0
+		supervisor.Add("foo", task.Temporary, func(ctx context.Context) error {
+			cnt++
+			return errors.New("fooErr")
+		})
+
+		err := supervisor.Run(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, cnt)
+	})
+
+	t.Run("it restarts a permanent task until the context is canceled", func(t *testing.T) {
+		t.Parallel()
+
+		supervisor := task.NewSupervisor(newTestSupervisorConfig(backofftest.NewAutoAdvanceFakeClock(time.Now())))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		cnt := 0
+		supervisor.Add("foo", task.Permanent, func(ctx context.Context) error {
+			cnt++
+			if cnt == 3 {
+				cancel()
+			}
+
+			return nil
+		})
+
+		err := supervisor.Run(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, cnt)
+	})
+
+	t.Run("when the max restarts are exceeded, it returns MaxRestartsExceededError", func(t *testing.T) {
+		t.Parallel()
+
+		config := newTestSupervisorConfig(backofftest.NewAutoAdvanceFakeClock(time.Now()))
+		config.MaxRestarts = 2
+		config.MaxRestartsPeriod = time.Hour
+
+		var restarted []string
+		config.OnRestart = func(name string, err error) {
+			restarted = append(restarted, name+": "+err.Error())
+		}
+
+		supervisor := task.NewSupervisor(config)
+
+		cnt := 0
+		supervisor.Add("foo", task.Permanent, func(ctx context.Context) error {
+			cnt++
+			return errors.New("fooErr")
+		})
+
+		err := supervisor.Run(context.Background())
+
+		require.IsType(t, (*task.MaxRestartsExceededError)(nil), err)
+		assert.EqualError(t, err, `max restarts exceeded by task "foo", last err: fooErr`)
+		assert.Equal(t, 3, cnt)
+		assert.Equal(t, []string{"foo: fooErr", "foo: fooErr"}, restarted)
+	})
+
+	t.Run("when a task stops after running healthy, it deposits to the retry budget", func(t *testing.T) {
+		t.Parallel()
+
+		clock := backofftest.NewAutoAdvanceFakeClock(time.Now())
+		config := newTestSupervisorConfig(clock)
+		config.HealthyAfter = time.Minute
+		config.RetryBudget = task.NewTokenBucketRetryBudget(&task.RetryBudgetConfig{
+			RetryRatio:          1,
+			MinRetriesPerSecond: 1e-9,
+			MaxTokens:           1,
+			Clock:               clock,
+		})
+
+		supervisor := task.NewSupervisor(config)
+
+		cnt := 0
+		supervisor.Add("foo", task.Transient, func(ctx context.Context) error {
+			cnt++
+			if cnt > 3 {
+				return nil
+			}
+
+			clock.Advance(time.Minute)
+
+			return errors.New("fooErr")
+		})
+
+		err := supervisor.Run(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 4, cnt)
+	})
+
+	t.Run("when the strategy is OneForOne, it restarts only the stopped task", func(t *testing.T) {
+		t.Parallel()
+
+		supervisor := task.NewSupervisor(newTestSupervisorConfig(backofftest.NewAutoAdvanceFakeClock(time.Now())))
+
+		var fooCnt, barCnt int32
+		supervisor.Add("foo", task.Transient, func(ctx context.Context) error {
+			if atomic.AddInt32(&fooCnt, 1) < 3 {
+				return errors.New("fooErr")
+			}
+
+			return nil
+		})
+		supervisor.Add("bar", task.Transient, func(ctx context.Context) error {
+			atomic.AddInt32(&barCnt, 1)
+			return nil
+		})
+
+		err := supervisor.Run(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&fooCnt))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&barCnt))
+	})
+
+	t.Run("when the strategy is OneForAll, it restarts all the tasks", func(t *testing.T) {
+		t.Parallel()
+
+		config := newTestSupervisorConfig(backofftest.NewAutoAdvanceFakeClock(time.Now()))
+		config.Strategy = task.OneForAll
+		supervisor := task.NewSupervisor(config)
+
+		barRunning := make(chan struct{}, 2)
+
+		var fooCnt, barCnt, bazCnt int32
+		supervisor.Add("foo", task.Transient, func(ctx context.Context) error {
+			<-barRunning
+			if atomic.AddInt32(&fooCnt, 1) < 2 {
+				return errors.New("fooErr")
+			}
+
+			return nil
+		})
+		supervisor.Add("bar", task.Transient, func(ctx context.Context) error {
+			atomic.AddInt32(&barCnt, 1)
+			barRunning <- struct{}{}
+			<-ctx.Done()
+
+			return nil
+		})
+		supervisor.Add("baz", task.Temporary, func(ctx context.Context) error {
+			atomic.AddInt32(&bazCnt, 1)
+			<-ctx.Done()
+
+			return nil
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go func() {
+			for atomic.LoadInt32(&fooCnt) < 2 {
+				time.Sleep(time.Millisecond)
+			}
+
+			cancel()
+		}()
+
+		err := supervisor.Run(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&fooCnt))
+		assert.Equal(t, int32(2), atomic.LoadInt32(&barCnt))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&bazCnt))
+	})
+}